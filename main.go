@@ -18,6 +18,21 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+// Exit codes of driftctl, scripts and CI jobs rely on them to tell a drift
+// from a failure
+const (
+	// exitCodeOK is returned when the infrastructure is in sync
+	exitCodeOK = 0
+	// exitCodeError is returned when the infrastructure is not in sync, when
+	// the drift got worse or when driftctl failed to run
+	exitCodeError = 1
+	// exitCodePanic is returned when driftctl crashed
+	exitCodePanic = 2
+	// exitCodePolicyViolated is returned when the drift exceeds the thresholds
+	// of the drift policy flags
+	exitCodePolicyViolated = 3
+)
+
 func init() {
 	_ = godotenv.Load(".env.local")
 	_ = godotenv.Load() // The Original .env
@@ -51,7 +66,7 @@ func run() int {
 				gosentry.CurrentHub().Recover(err)
 				flushSentry()
 				logrus.Fatalf("Captured panic: %s", err)
-				os.Exit(2)
+				os.Exit(exitCodePanic)
 			}
 			flushSentry()
 		}
@@ -59,13 +74,19 @@ func run() int {
 
 	if _, err := driftctlCmd.ExecuteC(); err != nil {
 		if _, isNotInSync := err.(cmderrors.InfrastructureNotInSync); isNotInSync {
-			return 1
+			return exitCodeError
+		}
+		if _, isWorse := err.(cmderrors.DriftGotWorse); isWorse {
+			return exitCodeError
+		}
+		if _, isViolated := err.(cmderrors.PolicyViolated); isViolated {
+			return exitCodePolicyViolated
 		}
 		if cmd.IsReportingEnabled(&driftctlCmd.Command) {
 			sentry.CaptureException(err)
 		}
 		_, _ = fmt.Fprintln(os.Stderr, color.RedString("%s", err))
-		return 1
+		return exitCodeError
 	}
 
 	if checkVersion {
@@ -76,7 +97,7 @@ func run() int {
 		}
 	}
 
-	return 0
+	return exitCodeOK
 }
 
 func flushSentry() {
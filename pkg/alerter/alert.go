@@ -1,6 +1,9 @@
 package alerter
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"reflect"
+)
 
 type Alerts map[string][]Alert
 
@@ -24,10 +27,15 @@ func (f *FakeAlert) ShouldIgnoreResource() bool {
 
 type SerializableAlert struct {
 	Alert
+	// WithType also serializes the type of the alert and whether it ignores
+	// resources, so they are kept when the alert is deserialized
+	WithType bool
 }
 
 type SerializedAlert struct {
-	Msg string `json:"message"`
+	Msg            string `json:"message"`
+	Type           string `json:"type,omitempty"`
+	IgnoreResource bool   `json:"ignore_resource,omitempty"`
 }
 
 func (u *SerializedAlert) Message() string {
@@ -35,7 +43,20 @@ func (u *SerializedAlert) Message() string {
 }
 
 func (u *SerializedAlert) ShouldIgnoreResource() bool {
-	return false
+	return u.IgnoreResource
+}
+
+// AlertType returns the name of the type of an alert, deserialized alerts
+// return the type of the alert they were serialized from
+func AlertType(alert Alert) string {
+	if serialized, ok := alert.(*SerializedAlert); ok {
+		return serialized.Type
+	}
+	ty := reflect.TypeOf(alert)
+	for ty.Kind() == reflect.Ptr {
+		ty = ty.Elem()
+	}
+	return ty.Name()
 }
 
 func (s *SerializableAlert) UnmarshalJSON(bytes []byte) error {
@@ -49,5 +70,12 @@ func (s *SerializableAlert) UnmarshalJSON(bytes []byte) error {
 }
 
 func (s *SerializableAlert) MarshalJSON() ([]byte, error) {
-	return json.Marshal(SerializedAlert{Msg: s.Message()})
+	if !s.WithType {
+		return json.Marshal(SerializedAlert{Msg: s.Message()})
+	}
+	return json.Marshal(SerializedAlert{
+		Msg:            s.Message(),
+		Type:           AlertType(s.Alert),
+		IgnoreResource: s.ShouldIgnoreResource(),
+	})
 }
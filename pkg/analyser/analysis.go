@@ -34,6 +34,15 @@ type Summary struct {
 	TotalManaged   int `json:"total_managed"`
 }
 
+// Versions of the serialized analysis schema
+const (
+	// SchemaVersionLegacy only carries resources id, type and source
+	SchemaVersionLegacy = 1
+	// SchemaVersionFull carries everything needed to rebuild an identical
+	// analysis: resources attributes, date, duration and changes metadata
+	SchemaVersionFull = 2
+)
+
 type Analysis struct {
 	unmanaged       []*resource.Resource
 	managed         []*resource.Resource
@@ -41,18 +50,28 @@ type Analysis struct {
 	differences     []Difference
 	summary         Summary
 	alerts          alerter.Alerts
+	policy          *PolicyResult
 	Duration        time.Duration
 	Date            time.Time
 	ProviderName    string
 	ProviderVersion string
+	// SchemaVersion is the schema used to serialize the analysis,
+	// it defaults to SchemaVersionLegacy when not set
+	SchemaVersion int
+}
+
+type serializableChange struct {
+	Change
+	JsonString bool `json:"json_string,omitempty"`
 }
 
 type serializableDifference struct {
 	Res       resource.SerializableResource `json:"res"`
-	Changelog Changelog                     `json:"changelog"`
+	Changelog []serializableChange          `json:"changelog"`
 }
 
 type serializableAnalysis struct {
+	Version         int                                    `json:"version,omitempty"`
 	Summary         Summary                                `json:"summary"`
 	Managed         []resource.SerializableResource        `json:"managed"`
 	Unmanaged       []resource.SerializableResource        `json:"unmanaged"`
@@ -62,6 +81,9 @@ type serializableAnalysis struct {
 	Alerts          map[string][]alerter.SerializableAlert `json:"alerts"`
 	ProviderName    string                                 `json:"provider_name"`
 	ProviderVersion string                                 `json:"provider_version"`
+	Date            *time.Time                             `json:"date,omitempty"`
+	Duration        time.Duration                          `json:"duration,omitempty"`
+	Policy          *PolicyResult                          `json:"policy,omitempty"`
 }
 
 type GenDriftIgnoreOptions struct {
@@ -72,28 +94,63 @@ type GenDriftIgnoreOptions struct {
 	OutputPath       string
 }
 
+func newSerializableDifference(difference Difference, full bool) serializableDifference {
+	res := resource.NewSerializableResource(difference.Res)
+	if full {
+		res = resource.NewSerializableResourceWithAttributes(difference.Res)
+	}
+	changelog := make([]serializableChange, 0, len(difference.Changelog))
+	for _, change := range difference.Changelog {
+		c := serializableChange{Change: change}
+		if full {
+			c.JsonString = change.JsonString
+		}
+		changelog = append(changelog, c)
+	}
+	return serializableDifference{
+		Res:       *res,
+		Changelog: changelog,
+	}
+}
+
+func (d serializableDifference) difference() Difference {
+	changelog := make(Changelog, 0, len(d.Changelog))
+	for _, change := range d.Changelog {
+		c := change.Change
+		c.JsonString = change.JsonString
+		changelog = append(changelog, c)
+	}
+	return Difference{
+		Res:       d.Res.Resource(),
+		Changelog: changelog,
+	}
+}
+
 func (a Analysis) MarshalJSON() ([]byte, error) {
+	full := a.SchemaVersion >= SchemaVersionFull
+	serialize := resource.NewSerializableResource
+	if full {
+		serialize = resource.NewSerializableResourceWithAttributes
+	}
+
 	bla := serializableAnalysis{}
 	for _, m := range a.managed {
-		bla.Managed = append(bla.Managed, *resource.NewSerializableResource(m))
+		bla.Managed = append(bla.Managed, *serialize(m))
 	}
 	for _, u := range a.unmanaged {
-		bla.Unmanaged = append(bla.Unmanaged, *resource.NewSerializableResource(u))
+		bla.Unmanaged = append(bla.Unmanaged, *serialize(u))
 	}
 	for _, d := range a.deleted {
-		bla.Deleted = append(bla.Deleted, *resource.NewSerializableResource(d))
+		bla.Deleted = append(bla.Deleted, *serialize(d))
 	}
 	for _, di := range a.differences {
-		bla.Differences = append(bla.Differences, serializableDifference{
-			Res:       *resource.NewSerializableResource(di.Res),
-			Changelog: di.Changelog,
-		})
+		bla.Differences = append(bla.Differences, newSerializableDifference(di, full))
 	}
 	if len(a.alerts) > 0 {
 		bla.Alerts = make(map[string][]alerter.SerializableAlert)
 		for k, v := range a.alerts {
 			for _, al := range v {
-				bla.Alerts[k] = append(bla.Alerts[k], alerter.SerializableAlert{Alert: al})
+				bla.Alerts[k] = append(bla.Alerts[k], alerter.SerializableAlert{Alert: al, WithType: full})
 			}
 		}
 	}
@@ -101,6 +158,14 @@ func (a Analysis) MarshalJSON() ([]byte, error) {
 	bla.Coverage = a.Coverage()
 	bla.ProviderName = a.ProviderName
 	bla.ProviderVersion = a.ProviderVersion
+	bla.Policy = a.policy
+
+	if full {
+		bla.Version = a.SchemaVersion
+		date := a.Date
+		bla.Date = &date
+		bla.Duration = a.Duration
+	}
 
 	return json.Marshal(bla)
 }
@@ -111,44 +176,33 @@ func (a *Analysis) UnmarshalJSON(bytes []byte) error {
 		return err
 	}
 	for _, u := range bla.Unmanaged {
-		a.AddUnmanaged(&resource.Resource{
-			Id:   u.Id,
-			Type: u.Type,
-		})
+		a.AddUnmanaged(u.Resource())
 	}
 	for _, d := range bla.Deleted {
-		a.AddDeleted(&resource.Resource{
-			Id:   d.Id,
-			Type: d.Type,
-		})
+		a.AddDeleted(d.Resource())
 	}
 	for _, m := range bla.Managed {
-		a.AddManaged(&resource.Resource{
-			Id:   m.Id,
-			Type: m.Type,
-		})
+		a.AddManaged(m.Resource())
 	}
 	for _, di := range bla.Differences {
-		a.AddDifference(Difference{
-			Res: &resource.Resource{
-				Id:   di.Res.Id,
-				Type: di.Res.Type,
-			},
-			Changelog: di.Changelog,
-		})
+		a.AddDifference(di.difference())
 	}
 	if len(bla.Alerts) > 0 {
 		a.alerts = make(alerter.Alerts)
 		for k, v := range bla.Alerts {
 			for _, al := range v {
-				a.alerts[k] = append(a.alerts[k], &alerter.SerializedAlert{
-					Msg: al.Message(),
-				})
+				a.alerts[k] = append(a.alerts[k], al.Alert)
 			}
 		}
 	}
 	a.ProviderName = bla.ProviderName
 	a.ProviderVersion = bla.ProviderVersion
+	a.SchemaVersion = bla.Version
+	a.policy = bla.Policy
+	if bla.Date != nil {
+		a.Date = *bla.Date
+	}
+	a.Duration = bla.Duration
 	return nil
 }
 
@@ -183,6 +237,10 @@ func (a *Analysis) SetAlerts(alerts alerter.Alerts) {
 	a.alerts = alerts
 }
 
+func (a *Analysis) SetPolicyResult(result *PolicyResult) {
+	a.policy = result
+}
+
 func (a *Analysis) Coverage() int {
 	if a.summary.TotalResources > 0 {
 		return int((float32(a.summary.TotalManaged) / float32(a.summary.TotalResources)) * 100.0)
@@ -214,6 +272,11 @@ func (a *Analysis) Alerts() alerter.Alerts {
 	return a.alerts
 }
 
+// PolicyResult returns nil when no policy has been evaluated
+func (a *Analysis) PolicyResult() *PolicyResult {
+	return a.policy
+}
+
 func (a *Analysis) SortResources() {
 	a.unmanaged = resource.Sort(a.unmanaged)
 	a.deleted = resource.Sort(a.deleted)
@@ -63,21 +63,26 @@ func (a Analyzer) Analyze(remoteResources, resourcesFromState []*resource.Resour
 		filteredRemoteResource = append(filteredRemoteResource, remoteRes)
 	}
 
+	index := newResourceIndex(filteredRemoteResource)
+
 	haveComputedDiff := false
 	for _, stateRes := range resourcesFromState {
-		i, remoteRes, found := findCorrespondingRes(filteredRemoteResource, stateRes)
-
 		if a.filter.IsResourceIgnored(stateRes) || a.alerter.IsResourceIgnored(stateRes) {
 			continue
 		}
 
+		// Remove managed resources from the index, so it will remain only unmanaged ones
+		remoteRes, found := index.take(stateRes)
 		if !found {
 			analysis.AddDeleted(stateRes)
 			continue
 		}
 
-		// Remove managed resources, so it will remain only unmanaged ones
-		filteredRemoteResource = removeResourceByIndex(i, filteredRemoteResource)
+		// States do not know where resources were found, like their account
+		if stateRes.Scope == nil {
+			stateRes.Scope = remoteRes.Scope
+		}
+
 		analysis.AddManaged(stateRes)
 
 		// Stop there if we are not in deep mode, we do not want to compute diffs
@@ -90,6 +95,11 @@ func (a Analyzer) Analyze(remoteResources, resourcesFromState []*resource.Resour
 			continue
 		}
 
+		// Stop if the IaC source does not know attributes, like CloudFormation stacks
+		if stateRes.Src() != nil && !stateRes.Src().AttributesKnown() {
+			continue
+		}
+
 		var delta diff.Changelog
 		delta, _ = diff.Diff(stateRes.Attributes(), remoteRes.Attributes())
 
@@ -121,6 +131,8 @@ func (a Analyzer) Analyze(remoteResources, resourcesFromState []*resource.Resour
 		}
 	}
 
+	filteredRemoteResource = index.remaining()
+
 	if a.hasUnmanagedSecurityGroupRules(filteredRemoteResource) {
 		a.alerter.SendAlert("", newUnmanagedSecurityGroupRulesAlert())
 	}
@@ -141,20 +153,81 @@ func (a Analyzer) Analyze(remoteResources, resourcesFromState []*resource.Resour
 	return analysis, nil
 }
 
-func findCorrespondingRes(resources []*resource.Resource, res *resource.Resource) (int, *resource.Resource, bool) {
-	for i, r := range resources {
-		if res.Equal(r) {
-			return i, r, true
+type resourceKey struct {
+	ty string
+	id string
+}
+
+// resourceIndex looks up remote resources by type and id, so matching a state
+// resource does not require to walk through every remote resource.
+// Resources sharing the same type and id are kept in their original order, the
+// first one that is still available is always picked. When the state resource
+// has a discriminant function, candidates are tested one by one with it.
+type resourceIndex struct {
+	resources  []*resource.Resource
+	taken      []bool
+	candidates map[resourceKey][]int
+}
+
+func newResourceIndex(resources []*resource.Resource) *resourceIndex {
+	index := &resourceIndex{
+		resources:  resources,
+		taken:      make([]bool, len(resources)),
+		candidates: make(map[resourceKey][]int, len(resources)),
+	}
+	for i, res := range resources {
+		key := resourceKey{res.ResourceType(), res.ResourceId()}
+		index.candidates[key] = append(index.candidates[key], i)
+	}
+	return index
+}
+
+// take returns the first remote resource matching the given resource and
+// removes it from the index
+func (i *resourceIndex) take(res *resource.Resource) (*resource.Resource, bool) {
+	key := resourceKey{res.ResourceType(), res.ResourceId()}
+	candidates := i.candidates[key]
+
+	if res.Schema() == nil || res.Schema().DiscriminantFunc == nil {
+		if len(candidates) == 0 {
+			return nil, false
+		}
+		i.remove(key, 0)
+		return i.resources[candidates[0]], true
+	}
+
+	for pos, candidate := range candidates {
+		if res.Equal(i.resources[candidate]) {
+			i.remove(key, pos)
+			return i.resources[candidate], true
 		}
 	}
-	return -1, nil, false
+	return nil, false
+}
+
+func (i *resourceIndex) remove(key resourceKey, pos int) {
+	candidates := i.candidates[key]
+	i.taken[candidates[pos]] = true
+	if len(candidates) == 1 {
+		delete(i.candidates, key)
+		return
+	}
+	if pos == 0 {
+		i.candidates[key] = candidates[1:]
+		return
+	}
+	i.candidates[key] = append(candidates[:pos], candidates[pos+1:]...)
 }
 
-func removeResourceByIndex(i int, resources []*resource.Resource) []*resource.Resource {
-	if i == len(resources)-1 {
-		return resources[:len(resources)-1]
+// remaining returns resources that have not been taken, in their original order
+func (i *resourceIndex) remaining() []*resource.Resource {
+	res := make([]*resource.Resource, 0, len(i.resources))
+	for pos, r := range i.resources {
+		if !i.taken[pos] {
+			res = append(res, r)
+		}
 	}
-	return append(resources[:i], resources[i+1:]...)
+	return res
 }
 
 // hasUnmanagedSecurityGroupRules returns true if we find at least one unmanaged
@@ -0,0 +1,66 @@
+package analyser
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cloudskiff/driftctl/pkg/alerter"
+	"github.com/cloudskiff/driftctl/pkg/resource"
+)
+
+type noopFilter struct{}
+
+func (noopFilter) IsTypeIgnored(resource.ResourceType) bool         { return false }
+func (noopFilter) IsResourceIgnored(*resource.Resource) bool        { return false }
+func (noopFilter) IsFieldIgnored(*resource.Resource, []string) bool { return false }
+
+// generateBenchResources returns remote and state resources where 80% of the
+// remote ones are managed, and 10% of the state ones are missing
+func generateBenchResources(count int) ([]*resource.Resource, []*resource.Resource) {
+	types := []string{"aws_s3_bucket", "aws_iam_role", "aws_instance", "aws_route", "aws_security_group"}
+	remote := make([]*resource.Resource, 0, count)
+	state := make([]*resource.Resource, 0, count)
+	for i := 0; i < count; i++ {
+		ty := types[i%len(types)]
+		res := &resource.Resource{
+			Id:    fmt.Sprintf("resource-%d", i),
+			Type:  ty,
+			Attrs: &resource.Attributes{"id": fmt.Sprintf("resource-%d", i)},
+		}
+		remote = append(remote, res)
+		if i%5 != 0 {
+			state = append(state, &resource.Resource{
+				Id:    res.Id,
+				Type:  res.Type,
+				Attrs: &resource.Attributes{"id": res.Id},
+			})
+		}
+		if i%10 == 0 {
+			state = append(state, &resource.Resource{
+				Id:    fmt.Sprintf("missing-%d", i),
+				Type:  ty,
+				Attrs: &resource.Attributes{},
+			})
+		}
+	}
+	return remote, state
+}
+
+func benchmarkAnalyze(b *testing.B, count int) {
+	remote, state := generateBenchResources(count)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		analyzer := NewAnalyzer(alerter.NewAlerter(), AnalyzerOptions{}, noopFilter{})
+		if _, err := analyzer.Analyze(remote, state); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkAnalyze10k(b *testing.B) {
+	benchmarkAnalyze(b, 10000)
+}
+
+func BenchmarkAnalyze100k(b *testing.B) {
+	benchmarkAnalyze(b, 100000)
+}
@@ -0,0 +1,130 @@
+package analyser
+
+import (
+	"encoding/json"
+
+	"github.com/cloudskiff/driftctl/pkg/resource"
+)
+
+// Comparison holds what changed between two analyses of the same infrastructure
+type Comparison struct {
+	NewUnmanaged        []*resource.Resource
+	NewManaged          []*resource.Resource
+	NewDeleted          []*resource.Resource
+	NewDifferences      []Difference
+	ResolvedDifferences []Difference
+	PreviousCoverage    int
+	CurrentCoverage     int
+}
+
+type serializableComparison struct {
+	NewUnmanaged        []resource.SerializableResource `json:"new_unmanaged"`
+	NewManaged          []resource.SerializableResource `json:"new_managed"`
+	NewDeleted          []resource.SerializableResource `json:"new_missing"`
+	NewDifferences      []serializableDifference        `json:"new_differences"`
+	ResolvedDifferences []serializableDifference        `json:"resolved_differences"`
+	PreviousCoverage    int                             `json:"previous_coverage"`
+	CurrentCoverage     int                             `json:"current_coverage"`
+	CoverageDelta       int                             `json:"coverage_delta"`
+	Worse               bool                            `json:"worse"`
+}
+
+// Compare computes what changed from the previous analysis to the current one.
+// Resources are matched on their type and id.
+func Compare(previous, current *Analysis) Comparison {
+	comparison := Comparison{
+		NewUnmanaged:     resourcesNotIn(current.Unmanaged(), previous.Unmanaged()),
+		NewManaged:       resourcesNotIn(current.Managed(), previous.Managed()),
+		NewDeleted:       resourcesNotIn(current.Deleted(), previous.Deleted()),
+		PreviousCoverage: previous.Coverage(),
+		CurrentCoverage:  current.Coverage(),
+	}
+
+	comparison.NewDifferences = differencesNotIn(current.Differences(), previous.Differences())
+	comparison.ResolvedDifferences = differencesNotIn(previous.Differences(), current.Differences())
+
+	return comparison
+}
+
+func (c Comparison) CoverageDelta() int {
+	return c.CurrentCoverage - c.PreviousCoverage
+}
+
+// IsWorse returns true when new drifts appeared or when the coverage decreased
+func (c Comparison) IsWorse() bool {
+	return len(c.NewUnmanaged) > 0 || len(c.NewDeleted) > 0 || len(c.NewDifferences) > 0 || c.CoverageDelta() < 0
+}
+
+func (c Comparison) IsEmpty() bool {
+	return len(c.NewUnmanaged) == 0 &&
+		len(c.NewManaged) == 0 &&
+		len(c.NewDeleted) == 0 &&
+		len(c.NewDifferences) == 0 &&
+		len(c.ResolvedDifferences) == 0 &&
+		c.CoverageDelta() == 0
+}
+
+func (c Comparison) MarshalJSON() ([]byte, error) {
+	bla := serializableComparison{
+		NewUnmanaged:        make([]resource.SerializableResource, 0, len(c.NewUnmanaged)),
+		NewManaged:          make([]resource.SerializableResource, 0, len(c.NewManaged)),
+		NewDeleted:          make([]resource.SerializableResource, 0, len(c.NewDeleted)),
+		NewDifferences:      make([]serializableDifference, 0, len(c.NewDifferences)),
+		ResolvedDifferences: make([]serializableDifference, 0, len(c.ResolvedDifferences)),
+		PreviousCoverage:    c.PreviousCoverage,
+		CurrentCoverage:     c.CurrentCoverage,
+		CoverageDelta:       c.CoverageDelta(),
+		Worse:               c.IsWorse(),
+	}
+	for _, u := range c.NewUnmanaged {
+		bla.NewUnmanaged = append(bla.NewUnmanaged, *resource.NewSerializableResource(u))
+	}
+	for _, m := range c.NewManaged {
+		bla.NewManaged = append(bla.NewManaged, *resource.NewSerializableResource(m))
+	}
+	for _, d := range c.NewDeleted {
+		bla.NewDeleted = append(bla.NewDeleted, *resource.NewSerializableResource(d))
+	}
+	for _, di := range c.NewDifferences {
+		bla.NewDifferences = append(bla.NewDifferences, newSerializableDifference(di, false))
+	}
+	for _, di := range c.ResolvedDifferences {
+		bla.ResolvedDifferences = append(bla.ResolvedDifferences, newSerializableDifference(di, false))
+	}
+
+	return json.Marshal(bla)
+}
+
+// resourcesNotIn returns resources from a that are not in b, sorted by type and id
+func resourcesNotIn(a, b []*resource.Resource) []*resource.Resource {
+	known := make(map[resourceKey]struct{}, len(b))
+	for _, res := range b {
+		known[resourceKey{res.ResourceType(), res.ResourceId()}] = struct{}{}
+	}
+
+	var result []*resource.Resource
+	for _, res := range a {
+		if _, exist := known[resourceKey{res.ResourceType(), res.ResourceId()}]; !exist {
+			result = append(result, res)
+		}
+	}
+
+	return resource.Sort(result)
+}
+
+// differencesNotIn returns differences from a whose resource is not drifted in b
+func differencesNotIn(a, b []Difference) []Difference {
+	known := make(map[resourceKey]struct{}, len(b))
+	for _, d := range b {
+		known[resourceKey{d.Res.ResourceType(), d.Res.ResourceId()}] = struct{}{}
+	}
+
+	var result []Difference
+	for _, d := range a {
+		if _, exist := known[resourceKey{d.Res.ResourceType(), d.Res.ResourceId()}]; !exist {
+			result = append(result, d)
+		}
+	}
+
+	return SortDifferences(result)
+}
@@ -0,0 +1,50 @@
+package analyser
+
+import (
+	"testing"
+
+	"github.com/cloudskiff/driftctl/pkg/resource"
+	"github.com/r3labs/diff/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompare(t *testing.T) {
+	bucket := &resource.Resource{Id: "bucket", Type: "aws_s3_bucket"}
+	user := &resource.Resource{Id: "user", Type: "aws_iam_user"}
+	role := &resource.Resource{Id: "role", Type: "aws_iam_role"}
+	difference := Difference{
+		Res:       bucket,
+		Changelog: Changelog{{Change: diff.Change{Type: diff.UPDATE, Path: []string{"acl"}, From: "private", To: "public"}}},
+	}
+
+	previous := &Analysis{}
+	previous.AddManaged(bucket)
+	previous.AddUnmanaged(user)
+
+	current := &Analysis{}
+	current.AddManaged(bucket, user)
+	current.AddDeleted(role)
+	current.AddDifference(difference)
+
+	comparison := Compare(previous, current)
+	assert.Empty(t, comparison.NewUnmanaged)
+	assert.Equal(t, []*resource.Resource{user}, comparison.NewManaged)
+	assert.Equal(t, []*resource.Resource{role}, comparison.NewDeleted)
+	assert.Equal(t, []Difference{difference}, comparison.NewDifferences)
+	assert.Empty(t, comparison.ResolvedDifferences)
+	assert.Equal(t, 50, comparison.PreviousCoverage)
+	assert.Equal(t, 66, comparison.CurrentCoverage)
+	assert.Equal(t, 16, comparison.CoverageDelta())
+	assert.True(t, comparison.IsWorse())
+	assert.False(t, comparison.IsEmpty())
+
+	comparison = Compare(current, previous)
+	assert.Equal(t, []*resource.Resource{user}, comparison.NewUnmanaged)
+	assert.Empty(t, comparison.NewDeleted)
+	assert.Equal(t, []Difference{difference}, comparison.ResolvedDifferences)
+	assert.True(t, comparison.IsWorse())
+
+	comparison = Compare(current, current)
+	assert.False(t, comparison.IsWorse())
+	assert.True(t, comparison.IsEmpty())
+}
@@ -0,0 +1,122 @@
+package analyser
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Policy rules names
+const (
+	PolicyRuleMinCoverage     = "min_coverage"
+	PolicyRuleMaxUnmanaged    = "max_unmanaged"
+	PolicyRuleMaxMissing      = "max_missing"
+	PolicyRuleMaxChanged      = "max_changed"
+	PolicyRuleMaxDriftPerType = "max_drift_per_type"
+)
+
+// Policy defines the drift budget an analysis should fit in.
+// A nil limit means the rule is disabled.
+type Policy struct {
+	MinCoverage  *int
+	MaxUnmanaged *int
+	MaxMissing   *int
+	MaxChanged   *int
+	// MaxDriftPerType limits the number of unmanaged, missing and changed
+	// resources of a given type
+	MaxDriftPerType map[string]int
+}
+
+type PolicyViolation struct {
+	Rule         string `json:"rule"`
+	ResourceType string `json:"resource_type,omitempty"`
+	Limit        int    `json:"limit"`
+	Value        int    `json:"value"`
+}
+
+func (v PolicyViolation) String() string {
+	switch v.Rule {
+	case PolicyRuleMinCoverage:
+		return fmt.Sprintf("coverage is %d%%, expected at least %d%%", v.Value, v.Limit)
+	case PolicyRuleMaxDriftPerType:
+		return fmt.Sprintf("found %d drifted %s resource(s), expected at most %d", v.Value, v.ResourceType, v.Limit)
+	default:
+		return fmt.Sprintf("%s: found %d resource(s), expected at most %d", v.Rule, v.Value, v.Limit)
+	}
+}
+
+type PolicyResult struct {
+	Violations []PolicyViolation `json:"violations"`
+}
+
+func (r *PolicyResult) IsViolated() bool {
+	return len(r.Violations) > 0
+}
+
+func (p Policy) IsEnabled() bool {
+	return p.MinCoverage != nil || p.MaxUnmanaged != nil || p.MaxMissing != nil || p.MaxChanged != nil || len(p.MaxDriftPerType) > 0
+}
+
+// Evaluate checks every enabled rule against the analysis
+func (p Policy) Evaluate(analysis *Analysis) *PolicyResult {
+	result := &PolicyResult{Violations: []PolicyViolation{}}
+	summary := analysis.Summary()
+
+	if p.MinCoverage != nil && analysis.Coverage() < *p.MinCoverage {
+		result.Violations = append(result.Violations, PolicyViolation{
+			Rule:  PolicyRuleMinCoverage,
+			Limit: *p.MinCoverage,
+			Value: analysis.Coverage(),
+		})
+	}
+
+	maxRules := []struct {
+		rule  string
+		limit *int
+		value int
+	}{
+		{PolicyRuleMaxUnmanaged, p.MaxUnmanaged, summary.TotalUnmanaged},
+		{PolicyRuleMaxMissing, p.MaxMissing, summary.TotalDeleted},
+		{PolicyRuleMaxChanged, p.MaxChanged, summary.TotalDrifted},
+	}
+	for _, r := range maxRules {
+		if r.limit != nil && r.value > *r.limit {
+			result.Violations = append(result.Violations, PolicyViolation{
+				Rule:  r.rule,
+				Limit: *r.limit,
+				Value: r.value,
+			})
+		}
+	}
+
+	if len(p.MaxDriftPerType) > 0 {
+		driftPerType := map[string]int{}
+		for _, res := range analysis.Unmanaged() {
+			driftPerType[res.ResourceType()]++
+		}
+		for _, res := range analysis.Deleted() {
+			driftPerType[res.ResourceType()]++
+		}
+		for _, d := range analysis.Differences() {
+			driftPerType[d.Res.ResourceType()]++
+		}
+
+		types := make([]string, 0, len(p.MaxDriftPerType))
+		for ty := range p.MaxDriftPerType {
+			types = append(types, ty)
+		}
+		sort.Strings(types)
+
+		for _, ty := range types {
+			if limit := p.MaxDriftPerType[ty]; driftPerType[ty] > limit {
+				result.Violations = append(result.Violations, PolicyViolation{
+					Rule:         PolicyRuleMaxDriftPerType,
+					ResourceType: ty,
+					Limit:        limit,
+					Value:        driftPerType[ty],
+				})
+			}
+		}
+	}
+
+	return result
+}
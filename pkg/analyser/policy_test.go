@@ -0,0 +1,103 @@
+package analyser
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/cloudskiff/driftctl/pkg/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func TestPolicy_Evaluate(t *testing.T) {
+	analysis := &Analysis{}
+	analysis.AddManaged(
+		&resource.Resource{Id: "bucket-1", Type: "aws_s3_bucket"},
+		&resource.Resource{Id: "bucket-2", Type: "aws_s3_bucket"},
+	)
+	analysis.AddUnmanaged(
+		&resource.Resource{Id: "user-1", Type: "aws_iam_user"},
+		&resource.Resource{Id: "user-2", Type: "aws_iam_user"},
+	)
+	analysis.AddDeleted(&resource.Resource{Id: "bucket-3", Type: "aws_s3_bucket"})
+	analysis.AddDifference(Difference{Res: &resource.Resource{Id: "bucket-1", Type: "aws_s3_bucket"}})
+
+	cases := []struct {
+		name     string
+		policy   Policy
+		enabled  bool
+		expected []PolicyViolation
+	}{
+		{
+			name:     "test empty policy",
+			policy:   Policy{},
+			enabled:  false,
+			expected: []PolicyViolation{},
+		},
+		{
+			name: "test drift within budget",
+			policy: Policy{
+				MinCoverage:     intPtr(40),
+				MaxUnmanaged:    intPtr(2),
+				MaxMissing:      intPtr(1),
+				MaxChanged:      intPtr(1),
+				MaxDriftPerType: map[string]int{"aws_s3_bucket": 2, "aws_iam_user": 2},
+			},
+			enabled:  true,
+			expected: []PolicyViolation{},
+		},
+		{
+			name: "test violated rules",
+			policy: Policy{
+				MinCoverage:     intPtr(50),
+				MaxUnmanaged:    intPtr(1),
+				MaxMissing:      intPtr(0),
+				MaxChanged:      intPtr(1),
+				MaxDriftPerType: map[string]int{"aws_s3_bucket": 1, "aws_iam_user": 2, "aws_iam_role": 0},
+			},
+			enabled: true,
+			expected: []PolicyViolation{
+				{Rule: PolicyRuleMinCoverage, Limit: 50, Value: 40},
+				{Rule: PolicyRuleMaxUnmanaged, Limit: 1, Value: 2},
+				{Rule: PolicyRuleMaxMissing, Limit: 0, Value: 1},
+				{Rule: PolicyRuleMaxDriftPerType, ResourceType: "aws_s3_bucket", Limit: 1, Value: 2},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.enabled, c.policy.IsEnabled())
+			result := c.policy.Evaluate(analysis)
+			assert.Equal(t, c.expected, result.Violations)
+			assert.Equal(t, len(c.expected) > 0, result.IsViolated())
+		})
+	}
+}
+
+func TestPolicyViolation_String(t *testing.T) {
+	assert.Equal(t, "coverage is 40%, expected at least 50%", PolicyViolation{Rule: PolicyRuleMinCoverage, Limit: 50, Value: 40}.String())
+	assert.Equal(t, "max_missing: found 1 resource(s), expected at most 0", PolicyViolation{Rule: PolicyRuleMaxMissing, Limit: 0, Value: 1}.String())
+	assert.Equal(t, "found 2 drifted aws_s3_bucket resource(s), expected at most 1", PolicyViolation{Rule: PolicyRuleMaxDriftPerType, ResourceType: "aws_s3_bucket", Limit: 1, Value: 2}.String())
+}
+
+func TestAnalysis_PolicyJSON(t *testing.T) {
+	analysis := Analysis{}
+	content, err := json.Marshal(analysis)
+	assert.Nil(t, err)
+	assert.NotContains(t, string(content), `"policy"`)
+
+	analysis.SetPolicyResult(&PolicyResult{Violations: []PolicyViolation{
+		{Rule: PolicyRuleMaxDriftPerType, ResourceType: "aws_s3_bucket", Limit: 1, Value: 2},
+	}})
+	content, err = json.Marshal(analysis)
+	assert.Nil(t, err)
+	assert.Contains(t, string(content), `"policy":{"violations":[{"rule":"max_drift_per_type","resource_type":"aws_s3_bucket","limit":1,"value":2}]}`)
+
+	got := Analysis{}
+	assert.Nil(t, json.Unmarshal(content, &got))
+	assert.Equal(t, analysis.PolicyResult(), got.PolicyResult())
+}
@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/cloudskiff/driftctl/pkg/analyser"
+	cmderrors "github.com/cloudskiff/driftctl/pkg/cmd/errors"
+	"github.com/cloudskiff/driftctl/pkg/cmd/scan/output"
+	"github.com/cloudskiff/driftctl/pkg/resource"
+	"github.com/fatih/color"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var supportedDiffOutputs = []string{
+	output.Example(output.ConsoleOutputType),
+	output.Example(output.JSONOutputType),
+}
+
+func NewDiffCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff <previous.json> <current.json>",
+		Short: "Compare two scan results",
+		Long:  "This command will compare two JSON scan results and report what changed between them\n\nExample: driftctl diff yesterday.json today.json",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputFlag, _ := cmd.Flags().GetString("output")
+			out, err := parseDiffOutputFlag(outputFlag)
+			if err != nil {
+				return err
+			}
+
+			previous, err := readAnalysis(args[0])
+			if err != nil {
+				return err
+			}
+			current, err := readAnalysis(args[1])
+			if err != nil {
+				return err
+			}
+
+			comparison := analyser.Compare(previous, current)
+
+			switch out.Key {
+			case output.JSONOutputType:
+				err = writeComparisonJSON(comparison, out.Path, cmd.OutOrStdout())
+			default:
+				writeComparisonConsole(comparison, cmd.OutOrStdout())
+			}
+			if err != nil {
+				return err
+			}
+
+			if comparison.IsWorse() {
+				return cmderrors.DriftGotWorse{}
+			}
+
+			return nil
+		},
+	}
+
+	fl := cmd.Flags()
+	fl.StringP(
+		"output",
+		"o",
+		output.Example(output.ConsoleOutputType),
+		"Output format, by default it will write to the console\n"+
+			"Accepted formats are: "+strings.Join(supportedDiffOutputs, ",")+"\n",
+	)
+
+	return cmd
+}
+
+func parseDiffOutputFlag(out string) (*output.OutputConfig, error) {
+	o, err := parseOutputFlag(out)
+	if err != nil {
+		return nil, err
+	}
+	if o.Key != output.ConsoleOutputType && o.Key != output.JSONOutputType {
+		return nil, errors.Wrapf(
+			cmderrors.NewUsageError(
+				fmt.Sprintf(
+					"\nValid formats are: %s",
+					strings.Join(supportedDiffOutputs, ","),
+				),
+			),
+			"Unsupported output '%s'",
+			o.Key,
+		)
+	}
+	return o, nil
+}
+
+func readAnalysis(path string) (*analyser.Analysis, error) {
+	input, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	analysis := &analyser.Analysis{}
+	if err := json.Unmarshal(input, analysis); err != nil {
+		return nil, errors.Wrapf(err, "unable to parse analysis from %s", path)
+	}
+
+	return analysis, nil
+}
+
+func writeComparisonJSON(comparison analyser.Comparison, path string, stdout io.Writer) error {
+	file := stdout
+	if path != "stdout" && path != "/dev/stdout" {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_TRUNC, 0600)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		file = f
+	}
+
+	content, err := json.MarshalIndent(comparison, "", "\t")
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(content)
+	return err
+}
+
+func writeComparisonConsole(comparison analyser.Comparison, w io.Writer) {
+	if comparison.IsEmpty() {
+		fmt.Fprintln(w, color.GreenString("Nothing changed since previous analysis"))
+		return
+	}
+
+	printResources := func(title string, resources []*resource.Resource) {
+		if len(resources) == 0 {
+			return
+		}
+		fmt.Fprintln(w, title)
+		for _, res := range resources {
+			fmt.Fprintf(w, "  - %s (%s)\n", res.ResourceId(), res.ResourceType())
+		}
+	}
+	printDifferences := func(title string, differences []analyser.Difference) {
+		if len(differences) == 0 {
+			return
+		}
+		fmt.Fprintln(w, title)
+		for _, d := range differences {
+			fmt.Fprintf(w, "  - %s (%s)\n", d.Res.ResourceId(), d.Res.ResourceType())
+		}
+	}
+
+	printResources("Resources that became unmanaged:", comparison.NewUnmanaged)
+	printResources("Resources that became managed:", comparison.NewManaged)
+	printResources("Newly missing resources:", comparison.NewDeleted)
+	printDifferences("Newly changed resources:", comparison.NewDifferences)
+	printDifferences("Resolved changes:", comparison.ResolvedDifferences)
+
+	delta := comparison.CoverageDelta()
+	deltaStr := fmt.Sprintf("%+d%%", delta)
+	if delta < 0 {
+		deltaStr = color.RedString(deltaStr)
+	} else if delta > 0 {
+		deltaStr = color.GreenString(deltaStr)
+	}
+	fmt.Fprintf(w, "Coverage went from %d%% to %d%% (%s)\n", comparison.PreviousCoverage, comparison.CurrentCoverage, deltaStr)
+
+	if comparison.IsWorse() {
+		fmt.Fprintln(w, color.RedString("Drift got worse since previous analysis"))
+	}
+}
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path"
+	"testing"
+
+	cmderrors "github.com/cloudskiff/driftctl/pkg/cmd/errors"
+	"github.com/cloudskiff/driftctl/test"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffCmd_Console(t *testing.T) {
+	cases := []struct {
+		name   string
+		args   []string
+		output string
+		err    error
+	}{
+		{
+			name:   "test nothing changed",
+			args:   []string{"./testdata/diff_previous.json", "./testdata/diff_previous.json"},
+			output: "Nothing changed since previous analysis\n",
+		},
+		{
+			name: "test drift got worse",
+			args: []string{"./testdata/diff_current.json", "./testdata/diff_previous.json"},
+			output: "Resources that became unmanaged:\n" +
+				"  - bucket-2 (aws_s3_bucket)\n" +
+				"Newly missing resources:\n" +
+				"  - old-role (aws_iam_role)\n" +
+				"Newly changed resources:\n" +
+				"  - bucket-1 (aws_s3_bucket)\n" +
+				"Coverage went from 50% to 25% (-25%)\n" +
+				"Drift got worse since previous analysis\n",
+			err: cmderrors.DriftGotWorse{},
+		},
+		{
+			name: "test new unmanaged resource",
+			args: []string{"./testdata/diff_previous.json", "./testdata/diff_current.json"},
+			output: "Resources that became unmanaged:\n" +
+				"  - sundowndev (aws_iam_user)\n" +
+				"Resources that became managed:\n" +
+				"  - bucket-2 (aws_s3_bucket)\n" +
+				"Resolved changes:\n" +
+				"  - bucket-1 (aws_s3_bucket)\n" +
+				"Coverage went from 25% to 50% (+25%)\n" +
+				"Drift got worse since previous analysis\n",
+			err: cmderrors.DriftGotWorse{},
+		},
+		{
+			name: "test error when input file does not exist",
+			args: []string{"./testdata/diff_previous.json", "doesnotexist"},
+			err:  errors.New("open doesnotexist: no such file or directory"),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			rootCmd := &cobra.Command{Use: "root", SilenceErrors: true, SilenceUsage: true}
+			rootCmd.AddCommand(NewDiffCmd())
+
+			output, err := test.Execute(rootCmd, append([]string{"diff"}, c.args...)...)
+			if c.err != nil {
+				assert.EqualError(t, err, c.err.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			if c.output != "" {
+				assert.Equal(t, c.output, output)
+			}
+		})
+	}
+}
+
+func TestDiffCmd_JSON(t *testing.T) {
+	rootCmd := &cobra.Command{Use: "root"}
+	rootCmd.AddCommand(NewDiffCmd())
+
+	outputPath := path.Join(t.TempDir(), "diff.json")
+	_, err := test.Execute(rootCmd, "diff", "./testdata/diff_previous.json", "./testdata/diff_current.json", "-o", "json://"+outputPath)
+	assert.Equal(t, cmderrors.DriftGotWorse{}, err)
+
+	content, err := os.ReadFile(outputPath)
+	require.Nil(t, err)
+
+	got := map[string]interface{}{}
+	require.Nil(t, json.Unmarshal(content, &got))
+	assert.Equal(t, []interface{}{map[string]interface{}{"id": "sundowndev", "type": "aws_iam_user"}}, got["new_unmanaged"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"id": "bucket-2", "type": "aws_s3_bucket"}}, got["new_managed"])
+	assert.Equal(t, []interface{}{}, got["new_missing"])
+	assert.Equal(t, []interface{}{}, got["new_differences"])
+	assert.Len(t, got["resolved_differences"], 1)
+	assert.Equal(t, 25.0, got["coverage_delta"])
+	assert.Equal(t, true, got["worse"])
+}
+
+func TestDiffCmd_InvalidFlags(t *testing.T) {
+	rootCmd := &cobra.Command{Use: "root"}
+	diffCmd := NewDiffCmd()
+	rootCmd.AddCommand(diffCmd)
+
+	cases := []struct {
+		args []string
+		err  string
+	}{
+		{args: []string{"diff", "a.json"}, err: "accepts 2 arg(s), received 1"},
+		{args: []string{"diff", "a.json", "b.json", "-o", "html://out.html"}, err: "Unsupported output 'html': \nValid formats are: console://,json://PATH/TO/FILE.json"},
+		{args: []string{"diff", "a.json", "b.json", "-o", "json://"}, err: "Invalid json output 'json://': \nMust be of kind: json://PATH/TO/FILE.json"},
+	}
+
+	for _, tt := range cases {
+		_, err := test.Execute(rootCmd, tt.args...)
+		assert.EqualError(t, err, tt.err)
+	}
+}
@@ -0,0 +1,7 @@
+package errors
+
+type DriftGotWorse struct{}
+
+func (i DriftGotWorse) Error() string {
+	return "Drift got worse since previous analysis"
+}
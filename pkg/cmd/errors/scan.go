@@ -5,3 +5,9 @@ type InfrastructureNotInSync struct{}
 func (i InfrastructureNotInSync) Error() string {
 	return "Infrastructure is not in sync"
 }
+
+type PolicyViolated struct{}
+
+func (i PolicyViolated) Error() string {
+	return "Drift policy has been violated"
+}
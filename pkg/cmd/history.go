@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/cloudskiff/driftctl/pkg/history"
+	"github.com/mitchellh/go-homedir"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const historyDateFormat = "2006-01-02 15:04:05"
+
+func NewHistoryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "Browse previous scans results",
+		Long:  "Browse scans recorded with the --history flag of the scan command\n\nExample: driftctl scan --history && driftctl history trend",
+		Args:  cobra.NoArgs,
+	}
+
+	configDir, err := homedir.Dir()
+	if err != nil {
+		configDir = os.TempDir()
+	}
+	cmd.PersistentFlags().String(
+		"config-dir",
+		configDir,
+		"Directory path that driftctl uses for configuration.\n",
+	)
+
+	cmd.AddCommand(newHistoryListCmd())
+	cmd.AddCommand(newHistoryTrendCmd())
+	cmd.AddCommand(newHistoryPruneCmd())
+
+	return cmd
+}
+
+func historyStore(cmd *cobra.Command) *history.Store {
+	configDir, _ := cmd.Flags().GetString("config-dir")
+	return history.NewStore(configDir)
+}
+
+func newHistoryListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List recorded scans",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			entries, err := historyStore(cmd).List()
+			if err != nil {
+				return err
+			}
+			writeHistoryList(cmd.OutOrStdout(), entries)
+			return nil
+		},
+	}
+}
+
+func newHistoryTrendCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "trend",
+		Short: "Show coverage and drift trends over recorded scans",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			entries, err := historyStore(cmd).List()
+			if err != nil {
+				return err
+			}
+			last, _ := cmd.Flags().GetInt("last")
+			if last > 0 && len(entries) > last {
+				entries = entries[len(entries)-last:]
+			}
+			writeHistoryTrend(cmd.OutOrStdout(), entries)
+			return nil
+		},
+	}
+
+	cmd.Flags().Int("last", 0, "Only show the given number of most recent scans")
+
+	return cmd
+}
+
+func newHistoryPruneCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Remove old recorded scans",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			keep, _ := cmd.Flags().GetInt("keep")
+			olderThan, _ := cmd.Flags().GetDuration("older-than")
+			if keep < 0 && olderThan <= 0 {
+				return errors.New("At least one of --keep or --older-than should be specified")
+			}
+
+			before := time.Time{}
+			if olderThan > 0 {
+				before = time.Now().Add(-olderThan)
+			}
+
+			removed, err := historyStore(cmd).Prune(before, keep)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Removed %d scan(s) from history\n", removed)
+			return nil
+		},
+	}
+
+	fl := cmd.Flags()
+	fl.Int("keep", -1, "Keep only the given number of most recent scans")
+	fl.Duration("older-than", 0, "Remove scans older than the given duration (e.g. 720h)")
+
+	return cmd
+}
+
+func writeHistoryList(w io.Writer, entries []history.Entry) {
+	if len(entries) == 0 {
+		fmt.Fprintln(w, "No scan recorded yet, use the --history flag of the scan command")
+		return
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tDATE\tPROVIDER\tCOVERAGE\tMANAGED\tUNMANAGED\tMISSING\tCHANGED\tSOURCES")
+	for _, entry := range entries {
+		summary := entry.Analysis.Summary()
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d%%\t%d\t%d\t%d\t%d\t%s\n",
+			entry.Id,
+			entry.Date.Format(historyDateFormat),
+			strings.TrimSpace(fmt.Sprintf("%s %s", entry.ProviderName, entry.ProviderVersion)),
+			entry.Analysis.Coverage(),
+			summary.TotalManaged,
+			summary.TotalUnmanaged,
+			summary.TotalDeleted,
+			summary.TotalDrifted,
+			strings.Join(entry.Sources, ","),
+		)
+	}
+	_ = tw.Flush()
+}
+
+func writeHistoryTrend(w io.Writer, entries []history.Entry) {
+	if len(entries) == 0 {
+		fmt.Fprintln(w, "No scan recorded yet, use the --history flag of the scan command")
+		return
+	}
+
+	driftCount := func(entry history.Entry) int {
+		summary := entry.Analysis.Summary()
+		return summary.TotalUnmanaged + summary.TotalDeleted + summary.TotalDrifted
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "DATE\tCOVERAGE\tDRIFTED")
+	for i, entry := range entries {
+		coverage := fmt.Sprintf("%d%%", entry.Analysis.Coverage())
+		drifted := fmt.Sprintf("%d", driftCount(entry))
+		if i > 0 {
+			coverage += fmt.Sprintf(" (%+d%%)", entry.Analysis.Coverage()-entries[i-1].Analysis.Coverage())
+			drifted += fmt.Sprintf(" (%+d)", driftCount(entry)-driftCount(entries[i-1]))
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", entry.Date.Format(historyDateFormat), coverage, drifted)
+	}
+	_ = tw.Flush()
+
+	first, last := entries[0], entries[len(entries)-1]
+	fmt.Fprintf(w, "\nOver %d scan(s), coverage went from %d%% to %d%% and drifted resources from %d to %d\n",
+		len(entries),
+		first.Analysis.Coverage(),
+		last.Analysis.Coverage(),
+		driftCount(first),
+		driftCount(last),
+	)
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cloudskiff/driftctl/pkg/analyser"
+	"github.com/cloudskiff/driftctl/pkg/history"
+	"github.com/cloudskiff/driftctl/pkg/resource"
+	"github.com/cloudskiff/driftctl/test"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fakeHistory(t *testing.T) string {
+	configDir := t.TempDir()
+	store := history.NewStore(configDir)
+
+	for i, unmanaged := range []int{3, 1} {
+		a := &analyser.Analysis{
+			Date:            time.Date(2021, 10, 1+i, 12, 0, 0, 0, time.UTC),
+			ProviderName:    "AWS",
+			ProviderVersion: "3.19.0",
+		}
+		a.AddManaged(&resource.Resource{Id: "bucket", Type: "aws_s3_bucket"})
+		for j := 0; j < unmanaged; j++ {
+			a.AddUnmanaged(&resource.Resource{Id: "user", Type: "aws_iam_user"})
+		}
+		_, err := store.Append(history.NewEntry(a, []string{"tfstate://terraform.tfstate"}))
+		require.Nil(t, err)
+	}
+
+	return configDir
+}
+
+func TestHistoryCmd(t *testing.T) {
+	configDir := fakeHistory(t)
+
+	cases := []struct {
+		name     string
+		args     []string
+		expected string
+		err      string
+	}{
+		{
+			name: "test list",
+			args: []string{"history", "list", "--config-dir", configDir},
+			expected: "ID                          DATE                 PROVIDER    COVERAGE  MANAGED  UNMANAGED  MISSING  CHANGED  SOURCES\n" +
+				"20211001T120000.000000000Z  2021-10-01 12:00:00  AWS 3.19.0  25%       1        3          0        0        tfstate://terraform.tfstate\n" +
+				"20211002T120000.000000000Z  2021-10-02 12:00:00  AWS 3.19.0  50%       1        1          0        0        tfstate://terraform.tfstate\n",
+		},
+		{
+			name: "test trend",
+			args: []string{"history", "trend", "--config-dir", configDir},
+			expected: "DATE                 COVERAGE    DRIFTED\n" +
+				"2021-10-01 12:00:00  25%         3\n" +
+				"2021-10-02 12:00:00  50% (+25%)  1 (-2)\n" +
+				"\nOver 2 scan(s), coverage went from 25% to 50% and drifted resources from 3 to 1\n",
+		},
+		{
+			name: "test trend with last",
+			args: []string{"history", "trend", "--config-dir", configDir, "--last", "1"},
+			expected: "DATE                 COVERAGE  DRIFTED\n" +
+				"2021-10-02 12:00:00  50%       1\n" +
+				"\nOver 1 scan(s), coverage went from 50% to 50% and drifted resources from 1 to 1\n",
+		},
+		{
+			name:     "test empty history",
+			args:     []string{"history", "list", "--config-dir", t.TempDir()},
+			expected: "No scan recorded yet, use the --history flag of the scan command\n",
+		},
+		{
+			name: "test prune without flags",
+			args: []string{"history", "prune", "--config-dir", configDir},
+			err:  "At least one of --keep or --older-than should be specified",
+		},
+		{
+			name:     "test prune",
+			args:     []string{"history", "prune", "--config-dir", configDir, "--keep", "1"},
+			expected: "Removed 1 scan(s) from history\n",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			rootCmd := &cobra.Command{Use: "root", SilenceErrors: true, SilenceUsage: true}
+			rootCmd.AddCommand(NewHistoryCmd())
+
+			output, err := test.Execute(rootCmd, c.args...)
+			if c.err != "" {
+				assert.EqualError(t, err, c.err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, c.expected, output)
+		})
+	}
+}
@@ -2,14 +2,20 @@ package cmd
 
 import (
 	"fmt"
+	"io/ioutil"
+	"net/url"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
 
+	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/cloudskiff/driftctl/pkg/analyser"
+	"github.com/cloudskiff/driftctl/pkg/history"
 	"github.com/cloudskiff/driftctl/pkg/memstore"
 	"github.com/cloudskiff/driftctl/pkg/remote/common"
 	"github.com/cloudskiff/driftctl/pkg/telemetry"
@@ -61,6 +67,30 @@ func NewScanCmd(opts *pkg.ScanOptions) *cobra.Command {
 				)
 			}
 
+			if err := validateAWSRegions(opts.RemoteOptions.AWSRegions, to); err != nil {
+				return err
+			}
+
+			rolesFile, _ := cmd.Flags().GetString("aws-assume-roles-file")
+			if rolesFile != "" {
+				roles, err := readAWSAssumeRolesFile(rolesFile)
+				if err != nil {
+					return err
+				}
+				opts.RemoteOptions.AWSAssumeRoles = append(opts.RemoteOptions.AWSAssumeRoles, roles...)
+			}
+			if err := validateAWSAssumeRoles(opts.RemoteOptions.AWSAssumeRoles, to); err != nil {
+				return err
+			}
+
+			if err := validateGCPScopes(opts.RemoteOptions.GCPScopes, to); err != nil {
+				return err
+			}
+
+			if err := validateAzureSubscriptions(opts.RemoteOptions.AzureSubscriptions, to); err != nil {
+				return err
+			}
+
 			outputFlag, _ := cmd.Flags().GetStringSlice("output")
 
 			out, err := parseOutputFlags(outputFlag)
@@ -83,6 +113,26 @@ func NewScanCmd(opts *pkg.ScanOptions) *cobra.Command {
 				opts.Filter = expr
 			}
 
+			if opts.OnlyFilteredTypes {
+				if opts.Filter == nil {
+					return errors.New("A filter expression is required to only scan filtered types")
+				}
+				types, ok := filter.ExpressionTypes(filterFlag[0])
+				if !ok {
+					return errors.New("Unable to derive resource types from filter expression, Type should be compared to strings (e.g. Type=='aws_s3_bucket')")
+				}
+				for _, ty := range types {
+					if !resource.IsResourceTypeSupported(ty.String()) {
+						return errors.Wrapf(
+							cmderrors.NewUsageError("\nOnly supported resource types can be scanned"),
+							"Unsupported resource type '%s' in filter expression",
+							ty,
+						)
+					}
+				}
+				opts.ScanTypes = resource.GetRelatedTypes(types)
+			}
+
 			providerVersion, _ := cmd.Flags().GetString("tf-provider-version")
 			if err := validateTfProviderVersionString(providerVersion); err != nil {
 				return err
@@ -91,6 +141,16 @@ func NewScanCmd(opts *pkg.ScanOptions) *cobra.Command {
 
 			if opts.ProviderVersion == "" {
 				lockfilePath, _ := cmd.Flags().GetString("tf-lockfile")
+				// The lock file of a Terraform working directory read with the workdir backend
+				// is used unless another lock file is given
+				if !cmd.Flags().Changed("tf-lockfile") {
+					for _, source := range opts.From {
+						if source.Backend == backend.BackendKeyWorkdir {
+							lockfilePath = filepath.Join(source.Path, ".terraform.lock.hcl")
+							break
+						}
+					}
+				}
 
 				// Attempt to read the provider version from a terraform lock file
 				lockFile, err := lock.ReadLocksFromFile(lockfilePath)
@@ -103,10 +163,38 @@ func NewScanCmd(opts *pkg.ScanOptions) *cobra.Command {
 				}
 			}
 
+			jsonSchemaVersion, _ := cmd.Flags().GetInt("json-schema-version")
+			if jsonSchemaVersion != analyser.SchemaVersionLegacy && jsonSchemaVersion != analyser.SchemaVersionFull {
+				return errors.Errorf(
+					"Invalid json schema version %d, accepted values are: %d,%d",
+					jsonSchemaVersion,
+					analyser.SchemaVersionLegacy,
+					analyser.SchemaVersionFull,
+				)
+			}
+			opts.JSONSchemaVersion = jsonSchemaVersion
+
+			policy, err := parsePolicyFlags(cmd)
+			if err != nil {
+				return err
+			}
+			opts.Policy = policy
+
 			opts.Quiet, _ = cmd.Flags().GetBool("quiet")
 			opts.DisableTelemetry, _ = cmd.Flags().GetBool("disable-telemetry")
 
 			opts.ConfigDir, _ = cmd.Flags().GetString("config-dir")
+			opts.History, _ = cmd.Flags().GetBool("history")
+
+			stateCache, _ := cmd.Flags().GetBool("state-cache")
+			noStateCache, _ := cmd.Flags().GetBool("no-state-cache")
+			if stateCache && !noStateCache {
+				maxSize, _ := cmd.Flags().GetInt64("state-cache-max-size")
+				if maxSize <= 0 {
+					return errors.New("State cache max size should be greater than 0")
+				}
+				opts.BackendOptions.StateCache = backend.NewStateCache(opts.ConfigDir, maxSize*1024*1024)
+			}
 
 			return nil
 		},
@@ -137,7 +225,13 @@ func NewScanCmd(opts *pkg.ScanOptions) *cobra.Command {
 		"o",
 		[]string{output.Example(output.ConsoleOutputType)},
 		"Output format, by default it will write to the console\n"+
-			"Accepted formats are: "+strings.Join(output.SupportedOutputsExample(), ",")+"\n",
+			"Accepted formats are: "+strings.Join(output.SupportedOutputsExample(), ",")+"\n"+
+			fmt.Sprintf("Markdown report is capped to %d bytes, use %s?%s=N to change it\n", output.MarkdownDefaultMaxSize, output.MarkdownOutputExample, output.MarkdownMaxSizeOption),
+	)
+	fl.Int(
+		"json-schema-version",
+		analyser.SchemaVersionLegacy,
+		fmt.Sprintf("Schema version of the JSON output, version %d also includes resources attributes, scan date and duration\n", analyser.SchemaVersionFull),
 	)
 	fl.StringSliceP(
 		"from",
@@ -155,6 +249,46 @@ func NewScanCmd(opts *pkg.ScanOptions) *cobra.Command {
 		"Cloud provider source\n"+
 			"Accepted values are: "+strings.Join(supportedRemotes, ",")+"\n",
 	)
+	fl.StringSliceVar(&opts.RemoteOptions.AWSRegions,
+		"aws-regions",
+		[]string{},
+		"AWS regions to scan, by default only the region of the AWS session is scanned\n"+
+			"Use "+common.AWSAllRegions+" to scan every region enabled for the account\n"+
+			"Only used with the "+common.RemoteAWSTerraform+" cloud provider.\n",
+	)
+	fl.StringSliceVar(&opts.RemoteOptions.AWSAssumeRoles,
+		"aws-assume-roles",
+		[]string{},
+		"ARNs of roles to assume to scan several AWS accounts, resources are grouped by account in the output\n"+
+			"Only used with the "+common.RemoteAWSTerraform+" cloud provider.\n",
+	)
+	fl.String(
+		"aws-assume-roles-file",
+		"",
+		"File listing ARNs of roles to assume, one per line\n"+
+			"Only used with the "+common.RemoteAWSTerraform+" cloud provider.\n",
+	)
+	fl.StringVar(&opts.RemoteOptions.AWSAssumeRoleExternalID,
+		"aws-assume-role-external-id",
+		"",
+		"External ID given when assuming AWS roles\n",
+	)
+	fl.StringSliceVar(&opts.RemoteOptions.GCPScopes,
+		"gcp-scopes",
+		[]string{},
+		"Google Cloud projects, folders or organizations to scan, by default only the project of the environment is scanned\n"+
+			"Example: --gcp-scopes projects/my-project,folders/123456789,organizations/987654321\n"+
+			"Resources are grouped by project in the output when several projects are scanned\n"+
+			"Only used with the "+common.RemoteGoogleTerraform+" cloud provider.\n",
+	)
+	fl.StringSliceVar(&opts.RemoteOptions.AzureSubscriptions,
+		"azure-subscriptions",
+		[]string{},
+		"Azure subscription IDs to scan, by default only the subscription of the environment is scanned\n"+
+			"Use "+common.AzureAllSubscriptions+" to scan every subscription visible to the principal\n"+
+			"Resources are grouped by subscription in the output when several subscriptions are scanned\n"+
+			"Only used with the "+common.RemoteAzureTerraform+" cloud provider.\n",
+	)
 	fl.StringToStringVarP(&opts.BackendOptions.Headers,
 		"headers",
 		"H",
@@ -190,15 +324,59 @@ func NewScanCmd(opts *pkg.ScanOptions) *cobra.Command {
 		fmt.Sprintf("%s Enable deep mode\n", warn("EXPERIMENTAL:"))+
 			"You should check the documentation for more details: https://docs.driftctl.com/deep-mode\n",
 	)
+	fl.BoolVar(&opts.OnlyManagedTypes,
+		"only-managed-types",
+		false,
+		"Only scan cloud resources of types found in IaC\n"+
+			"Resources of other types are not reported as unmanaged, making the coverage partial\n"+
+			"Every type is scanned when no resource is found in IaC\n",
+	)
+	fl.BoolVar(&opts.OnlyFilteredTypes,
+		"only-filtered-types",
+		false,
+		"Only scan cloud resources of types matched by the filter expression\n"+
+			"Types are derived from the comparisons of the Type field (e.g. Type=='aws_s3_bucket')\n",
+	)
 	fl.StringVar(&opts.DriftignorePath,
 		"driftignore",
 		".driftignore",
-		"Path to the driftignore file",
+		"Path to the driftignore file\n"+
+			"When left to the default, .driftignore files next to local states are read too.\n"+
+			"Their rules only apply to resources of the state and take precedence over the default file.\n",
 	)
 	fl.String(
 		"tf-lockfile",
 		".terraform.lock.hcl",
-		"Terraform lock file to get the provider's version from. Will be ignored if the file doesn't exist.\n",
+		"Terraform lock file to get the provider's version from. Will be ignored if the file doesn't exist.\n"+
+			"Defaults to the lock file of the first tfstate+workdir source, if any.\n",
+	)
+
+	fl.Int(
+		"min-coverage",
+		0,
+		"Fail only when the coverage percentage is below this value\n"+
+			"A scan failing because of a drift policy flag exits with code 3\n",
+	)
+	fl.Int(
+		"max-unmanaged",
+		0,
+		"Fail only when more resources than this value are not covered by IaC\n",
+	)
+	fl.Int(
+		"max-missing",
+		0,
+		"Fail only when more resources than this value are missing\n",
+	)
+	fl.Int(
+		"max-changed",
+		0,
+		"Fail only when more resources than this value have changed\n",
+	)
+	fl.StringToInt(
+		"max-drift-per-type",
+		map[string]int{},
+		"Fail only when more unmanaged, missing or changed resources of a type than the given value are found\n"+
+			"Example: --max-drift-per-type aws_s3_bucket=0,aws_iam_user=5\n",
 	)
 
 	configDir, err := homedir.Dir()
@@ -210,6 +388,28 @@ func NewScanCmd(opts *pkg.ScanOptions) *cobra.Command {
 		configDir,
 		"Directory path that driftctl uses for configuration.\n",
 	)
+	fl.Bool(
+		"history",
+		false,
+		"Record the scan result in the local history under the config directory\n"+
+			"Use the history command to browse it\n",
+	)
+	fl.Bool(
+		"state-cache",
+		false,
+		"Cache states read from S3, HTTP(s) and Terraform Cloud backends under the config directory\n"+
+			"Unchanged states are not downloaded again, the cache is disabled by default\n",
+	)
+	fl.Int64(
+		"state-cache-max-size",
+		backend.StateCacheDefaultMaxSize/(1024*1024),
+		"Size limit of the state cache in megabytes, least recently used states are evicted first\n",
+	)
+	fl.Bool(
+		"no-state-cache",
+		false,
+		"Neither read nor write the state cache, even if it is enabled with --state-cache or DCTL_STATE_CACHE\n",
+	)
 
 	return cmd
 }
@@ -238,7 +438,7 @@ func scanRun(opts *pkg.ScanOptions) error {
 
 	resFactory := terraform.NewTerraformResourceFactory(resourceSchemaRepository)
 
-	err := remote.Activate(opts.To, opts.ProviderVersion, alerter, providerLibrary, remoteLibrary, scanProgress, resourceSchemaRepository, resFactory, opts.ConfigDir)
+	err := remote.Activate(opts.To, opts.ProviderVersion, alerter, providerLibrary, remoteLibrary, scanProgress, resourceSchemaRepository, resFactory, opts.ConfigDir, opts.RemoteOptions)
 	if err != nil {
 		return err
 	}
@@ -253,9 +453,9 @@ func scanRun(opts *pkg.ScanOptions) error {
 	logrus.Debug("Checking for driftignore")
 	driftIgnore := filter.NewDriftIgnore(opts.DriftignorePath)
 
-	scanner := remote.NewScanner(remoteLibrary, alerter, remote.ScannerOptions{Deep: opts.Deep}, driftIgnore)
+	scanner := remote.NewScanner(remoteLibrary, alerter, remote.ScannerOptions{Deep: opts.Deep, Types: opts.ScanTypes}, driftIgnore)
 
-	iacSupplier, err := supplier.GetIACSupplier(opts.From, providerLibrary, opts.BackendOptions, iacProgress, alerter, resFactory, driftIgnore)
+	iacSupplier, err := supplier.GetIACSupplier(opts.From, providerLibrary, opts.BackendOptions, iacProgress, alerter, resFactory, resourceSchemaRepository, driftIgnore)
 	if err != nil {
 		return err
 	}
@@ -286,6 +486,15 @@ func scanRun(opts *pkg.ScanOptions) error {
 
 	analysis.ProviderVersion = resourceSchemaRepository.ProviderVersion.String()
 	analysis.ProviderName = resourceSchemaRepository.ProviderName
+	analysis.SchemaVersion = opts.JSONSchemaVersion
+
+	if opts.Policy.IsEnabled() {
+		analysis.SetPolicyResult(opts.Policy.Evaluate(analysis))
+	}
+
+	if opts.History {
+		recordHistory(opts, analysis)
+	}
 	store.Bucket(memstore.TelemetryBucket).Set("provider_name", analysis.ProviderName)
 
 	validOutput := false
@@ -312,6 +521,20 @@ func scanRun(opts *pkg.ScanOptions) error {
 		telemetry.SendTelemetry(store.Bucket(memstore.TelemetryBucket))
 	}
 
+	if policyResult := analysis.PolicyResult(); policyResult != nil {
+		if policyResult.IsViolated() {
+			globaloutput.Printf(color.RedString("\nDrift policy violated:\n"))
+			for _, violation := range policyResult.Violations {
+				globaloutput.Printf(color.RedString(" - %s\n", violation.String()))
+			}
+			return cmderrors.PolicyViolated{}
+		}
+		if !analysis.IsSync() {
+			globaloutput.Printf(color.YellowString("\nDrift found but within the policy budget\n"))
+		}
+		return nil
+	}
+
 	if !analysis.IsSync() {
 		globaloutput.Printf("\nHint: use gen-driftignore command to generate a .driftignore file based on your drifts\n")
 
@@ -321,6 +544,64 @@ func scanRun(opts *pkg.ScanOptions) error {
 	return nil
 }
 
+// recordHistory appends the analysis to the local history, a failure there
+// should not fail the scan
+func recordHistory(opts *pkg.ScanOptions, analysis *analyser.Analysis) {
+	sources := make([]string, 0, len(opts.From))
+	for _, from := range opts.From {
+		sources = append(sources, from.String())
+	}
+
+	store := history.NewStore(opts.ConfigDir)
+	id, err := store.Append(history.NewEntry(analysis, sources))
+	if err != nil {
+		logrus.WithField("error", err).Error("Unable to record scan in history")
+		return
+	}
+	logrus.WithFields(logrus.Fields{"id": id, "dir": store.Dir()}).Debug("Recorded scan in history")
+}
+
+// parsePolicyFlags builds the drift policy, only explicitly set flags enable
+// their rule
+func parsePolicyFlags(cmd *cobra.Command) (analyser.Policy, error) {
+	policy := analyser.Policy{}
+	fl := cmd.Flags()
+
+	limits := []struct {
+		flag  string
+		limit **int
+	}{
+		{"min-coverage", &policy.MinCoverage},
+		{"max-unmanaged", &policy.MaxUnmanaged},
+		{"max-missing", &policy.MaxMissing},
+		{"max-changed", &policy.MaxChanged},
+	}
+	for _, l := range limits {
+		if !fl.Changed(l.flag) {
+			continue
+		}
+		value, _ := fl.GetInt(l.flag)
+		if value < 0 {
+			return policy, errors.Errorf("Invalid value %d for --%s, expected a positive number", value, l.flag)
+		}
+		*l.limit = &value
+	}
+	if policy.MinCoverage != nil && *policy.MinCoverage > 100 {
+		return policy, errors.Errorf("Invalid value %d for --min-coverage, expected a percentage", *policy.MinCoverage)
+	}
+
+	if fl.Changed("max-drift-per-type") {
+		policy.MaxDriftPerType, _ = fl.GetStringToInt("max-drift-per-type")
+		for ty, value := range policy.MaxDriftPerType {
+			if value < 0 {
+				return policy, errors.Errorf("Invalid value %d for %s in --max-drift-per-type, expected a positive number", value, ty)
+			}
+		}
+	}
+
+	return policy, nil
+}
+
 func parseFromFlag(from []string) ([]config.SupplierConfig, error) {
 
 	configs := make([]config.SupplierConfig, 0, len(from))
@@ -372,12 +653,12 @@ func parseFromFlag(from []string) ([]config.SupplierConfig, error) {
 		backendString := ""
 		if len(supplierBackend) == 2 {
 			backendString = supplierBackend[1]
-			if !backend.IsSupported(backendString) {
+			if !supplier.IsBackendSupported(supplierKey, backendString) {
 				return nil, errors.Wrapf(
 					cmderrors.NewUsageError(
 						fmt.Sprintf(
 							"\nAccepted values are: %s",
-							strings.Join(backend.GetSupportedBackends(), ","),
+							strings.Join(supplier.GetSupportedBackends(supplierKey), ","),
 						),
 					),
 					"Unsupported IaC backend '%s'",
@@ -484,11 +765,91 @@ func parseOutputFlag(out string) (*output.OutputConfig, error) {
 			)
 		}
 		o.Path = opts[0]
+	case output.SARIFOutputType:
+		if len(opts) != 1 || opts[0] == "" {
+			return nil, errors.Wrapf(
+				cmderrors.NewUsageError(
+					fmt.Sprintf(
+						"\nMust be of kind: %s",
+						output.Example(output.SARIFOutputType),
+					),
+				),
+				"Invalid sarif output '%s'",
+				out,
+			)
+		}
+		o.Path = opts[0]
+	case output.JUnitOutputType:
+		if len(opts) != 1 || opts[0] == "" {
+			return nil, errors.Wrapf(
+				cmderrors.NewUsageError(
+					fmt.Sprintf(
+						"\nMust be of kind: %s",
+						output.Example(output.JUnitOutputType),
+					),
+				),
+				"Invalid junit output '%s'",
+				out,
+			)
+		}
+		o.Path = opts[0]
+	case output.MarkdownOutputType:
+		if len(opts) != 1 || opts[0] == "" || strings.HasPrefix(opts[0], "?") {
+			return nil, errors.Wrapf(
+				cmderrors.NewUsageError(
+					fmt.Sprintf(
+						"\nMust be of kind: %s",
+						output.Example(output.MarkdownOutputType),
+					),
+				),
+				"Invalid markdown output '%s'",
+				out,
+			)
+		}
+		pathQuery := strings.SplitN(opts[0], "?", 2)
+		o.Path = pathQuery[0]
+		if len(pathQuery) == 2 {
+			options, err := parseMarkdownOptions(pathQuery[1])
+			if err != nil {
+				return nil, errors.Wrapf(
+					cmderrors.NewUsageError(
+						fmt.Sprintf(
+							"\nMust be of kind: %s?%s=N, with N at least %d",
+							output.Example(output.MarkdownOutputType),
+							output.MarkdownMaxSizeOption,
+							output.MarkdownMinMaxSize,
+						),
+					),
+					"Invalid markdown output '%s'",
+					out,
+				)
+			}
+			o.Options = options
+		}
 	}
 
 	return o, nil
 }
 
+func parseMarkdownOptions(query string) (map[string]string, error) {
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	options := make(map[string]string, len(values))
+	for key := range values {
+		if key != output.MarkdownMaxSizeOption {
+			return nil, errors.Errorf("unknown option %s", key)
+		}
+		size, err := strconv.Atoi(values.Get(key))
+		if err != nil || size < output.MarkdownMinMaxSize {
+			return nil, errors.Errorf("invalid %s", key)
+		}
+		options[key] = values.Get(key)
+	}
+	return options, nil
+}
+
 func validateTfProviderVersionString(version string) error {
 	if version == "" {
 		return nil
@@ -498,3 +859,101 @@ func validateTfProviderVersionString(version string) error {
 	}
 	return nil
 }
+
+func validateAWSRegions(regions []string, to string) error {
+	if len(regions) == 0 {
+		return nil
+	}
+	if to != common.RemoteAWSTerraform {
+		return errors.Errorf("AWS regions can only be scanned with the %s cloud provider", common.RemoteAWSTerraform)
+	}
+	for _, region := range regions {
+		if region == common.AWSAllRegions && len(regions) > 1 {
+			return errors.Errorf("AWS region '%s' cannot be combined with other regions", common.AWSAllRegions)
+		}
+		if region == "" {
+			return errors.New("AWS region cannot be empty")
+		}
+	}
+	return nil
+}
+
+func validateAWSAssumeRoles(roles []string, to string) error {
+	if len(roles) == 0 {
+		return nil
+	}
+	if to != common.RemoteAWSTerraform {
+		return errors.Errorf("AWS roles can only be assumed with the %s cloud provider", common.RemoteAWSTerraform)
+	}
+	for _, role := range roles {
+		if _, err := arn.Parse(role); err != nil {
+			return errors.Errorf("Invalid AWS role ARN '%s'", role)
+		}
+	}
+	return nil
+}
+
+func validateGCPScopes(scopes []string, to string) error {
+	if len(scopes) == 0 {
+		return nil
+	}
+	if to != common.RemoteGoogleTerraform {
+		return errors.Errorf("Google Cloud scopes can only be scanned with the %s cloud provider", common.RemoteGoogleTerraform)
+	}
+	for _, scope := range scopes {
+		if !isValidGCPScope(scope) {
+			return errors.Errorf(
+				"Invalid Google Cloud scope '%s', expected one of %s followed by an ID",
+				scope,
+				strings.Join(common.GCPScopePrefixes, ","),
+			)
+		}
+	}
+	return nil
+}
+
+func isValidGCPScope(scope string) bool {
+	for _, prefix := range common.GCPScopePrefixes {
+		id := strings.TrimPrefix(scope, prefix)
+		if id != scope && id != "" && !strings.Contains(id, "/") {
+			return true
+		}
+	}
+	return false
+}
+
+func validateAzureSubscriptions(subscriptions []string, to string) error {
+	if len(subscriptions) == 0 {
+		return nil
+	}
+	if to != common.RemoteAzureTerraform {
+		return errors.Errorf("Azure subscriptions can only be scanned with the %s cloud provider", common.RemoteAzureTerraform)
+	}
+	for _, subscription := range subscriptions {
+		if subscription == common.AzureAllSubscriptions && len(subscriptions) > 1 {
+			return errors.Errorf("Azure subscription '%s' cannot be combined with other subscriptions", common.AzureAllSubscriptions)
+		}
+		if subscription == "" {
+			return errors.New("Azure subscription cannot be empty")
+		}
+	}
+	return nil
+}
+
+// readAWSAssumeRolesFile reads role ARNs from a file, ignoring blank lines and
+// lines starting with #
+func readAWSAssumeRolesFile(path string) ([]string, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read AWS roles file")
+	}
+	roles := make([]string, 0)
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		roles = append(roles, line)
+	}
+	return roles, nil
+}
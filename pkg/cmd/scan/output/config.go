@@ -3,8 +3,9 @@ package output
 import "fmt"
 
 type OutputConfig struct {
-	Key  string
-	Path string
+	Key     string
+	Path    string
+	Options map[string]string
 }
 
 func (o *OutputConfig) String() string {
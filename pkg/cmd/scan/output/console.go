@@ -10,6 +10,7 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws/awsutil"
 	"github.com/cloudskiff/driftctl/pkg/remote/alerts"
+	"github.com/cloudskiff/driftctl/pkg/remote/common"
 	"github.com/fatih/color"
 	"github.com/mattn/go-isatty"
 	"github.com/r3labs/diff/v2"
@@ -35,127 +36,51 @@ func NewConsole() *Console {
 
 func (c *Console) Write(analysis *analyser.Analysis) error {
 	if analysis.Summary().TotalDeleted > 0 {
-		var sources []string
-		groupedBySource := make(map[string][]*resource.Resource)
-
-		for _, deletedResource := range analysis.Deleted() {
-			key := ""
-			if deletedResource.Source != nil {
-				key = deletedResource.Source.Source()
-			}
-
-			if _, exist := groupedBySource[key]; !exist {
-				groupedBySource[key] = []*resource.Resource{deletedResource}
-				continue
-			}
-
-			groupedBySource[key] = append(groupedBySource[key], deletedResource)
-		}
-
-		for s := range groupedBySource {
-			sources = append(sources, s)
-		}
-		sort.Strings(sources)
-
 		fmt.Println("Found missing resources:")
-
-		for _, source := range sources {
+		deletedByAccount, accounts := groupByAccount(analysis.Deleted())
+		for _, account := range accounts {
 			indentBase := "  "
-			if source != "" {
-				fmt.Print(color.BlueString("%sFrom %s\n", indentBase, source))
-				indentBase += indentBase
-			}
-			for _, deletedResource := range groupedBySource[source] {
-				humanStringSource := deletedResource.ResourceType()
-				if deletedResource.SourceString() != "" {
-					humanStringSource = deletedResource.SourceString()
-				}
-				humanString := fmt.Sprintf("%s- %s (%s)", indentBase, deletedResource.ResourceId(), humanStringSource)
-
-				if humanAttrs := formatResourceAttributes(deletedResource); humanAttrs != "" {
-					humanString += fmt.Sprintf("\n%s    %s", indentBase, humanAttrs)
-				}
-				fmt.Println(humanString)
+			if account != "" {
+				fmt.Print(color.BlueString("%sIn %s\n", indentBase, account))
+				indentBase += "  "
 			}
+			c.writeDeleted(deletedByAccount[account], indentBase)
 		}
 	}
 
 	if analysis.Summary().TotalUnmanaged > 0 {
 		fmt.Println("Found resources not covered by IaC:")
-		unmanagedByType, keys := groupByType(analysis.Unmanaged())
-		for _, ty := range keys {
-			fmt.Printf("  %s:\n", ty)
-			for _, res := range unmanagedByType[ty] {
-				humanString := fmt.Sprintf("    - %s", res.ResourceId())
-				if humanAttrs := formatResourceAttributes(res); humanAttrs != "" {
-					humanString += fmt.Sprintf("\n        %s", humanAttrs)
+		unmanagedByAccount, accounts := groupByAccount(analysis.Unmanaged())
+		for _, account := range accounts {
+			indentBase := "  "
+			if account != "" {
+				fmt.Print(color.BlueString("%sIn %s\n", indentBase, account))
+				indentBase += "  "
+			}
+			unmanagedByType, keys := groupByType(unmanagedByAccount[account])
+			for _, ty := range keys {
+				fmt.Printf("%s%s:\n", indentBase, ty)
+				for _, res := range unmanagedByType[ty] {
+					humanString := fmt.Sprintf("%s  - %s", indentBase, res.ResourceId())
+					if humanAttrs := formatResourceAttributes(res); humanAttrs != "" {
+						humanString += fmt.Sprintf("\n%s      %s", indentBase, humanAttrs)
+					}
+					fmt.Println(humanString)
 				}
-				fmt.Println(humanString)
 			}
 		}
 	}
 
 	if analysis.Summary().TotalDrifted > 0 {
-		var sources []string
-		groupedBySource := make(map[string][]analyser.Difference)
-		for _, difference := range analysis.Differences() {
-			key := ""
-			if difference.Res.Source != nil {
-				key = difference.Res.Source.Source()
-			}
-			if _, exist := groupedBySource[key]; !exist {
-				groupedBySource[key] = []analyser.Difference{difference}
-				continue
-			}
-			groupedBySource[key] = append(groupedBySource[key], difference)
-		}
-
-		for s := range groupedBySource {
-			sources = append(sources, s)
-		}
-		sort.Strings(sources)
-
 		fmt.Println("Found changed resources:")
-		for _, source := range sources {
+		differencesByAccount, accounts := groupDifferencesByAccount(analysis.Differences())
+		for _, account := range accounts {
 			indentBase := "  "
-			if source != "" {
-				fmt.Print(color.BlueString("%sFrom %s\n", indentBase, source))
-				indentBase += indentBase
-			}
-			for _, difference := range groupedBySource[source] {
-				humanStringSource := difference.Res.ResourceType()
-				if difference.Res.SourceString() != "" {
-					humanStringSource = difference.Res.SourceString()
-				}
-				humanString := fmt.Sprintf("%s- %s (%s):", indentBase, difference.Res.ResourceId(), humanStringSource)
-				whiteSpace := indentBase + "    "
-				if humanAttrs := formatResourceAttributes(difference.Res); humanAttrs != "" {
-					humanString += fmt.Sprintf("\n%s%s", whiteSpace, humanAttrs)
-					whiteSpace += "    "
-				}
-				fmt.Println(humanString)
-				for _, change := range difference.Changelog {
-					path := strings.Join(change.Path, ".")
-					pref := fmt.Sprintf("%s %s:", color.YellowString("~"), path)
-					if change.Type == diff.CREATE {
-						pref = fmt.Sprintf("%s %s:", color.GreenString("+"), path)
-					} else if change.Type == diff.DELETE {
-						pref = fmt.Sprintf("%s %s:", color.RedString("-"), path)
-					}
-					if change.Type == diff.UPDATE {
-						if change.JsonString {
-							prefix := "           "
-							fmt.Printf("%s%s\n%s%s\n", whiteSpace, pref, prefix, jsonDiff(change.From, change.To, isatty.IsTerminal(os.Stdout.Fd())))
-							continue
-						}
-					}
-					fmt.Printf("%s%s %s => %s", whiteSpace, pref, prettify(change.From), prettify(change.To))
-					if change.Computed {
-						fmt.Printf(" %s", color.YellowString("(computed)"))
-					}
-					fmt.Printf("\n")
-				}
+			if account != "" {
+				fmt.Print(color.BlueString("%sIn %s\n", indentBase, account))
+				indentBase += "  "
 			}
+			c.writeDifferences(differencesByAccount[account], indentBase)
 		}
 	}
 
@@ -178,6 +103,115 @@ func (c *Console) Write(analysis *analyser.Analysis) error {
 	return nil
 }
 
+// writeDeleted prints missing resources grouped by their IaC source
+func (c *Console) writeDeleted(resources []*resource.Resource, indent string) {
+	var sources []string
+	groupedBySource := make(map[string][]*resource.Resource)
+
+	for _, deletedResource := range resources {
+		key := ""
+		if deletedResource.Source != nil {
+			key = formatResourceSource(deletedResource.Source)
+		}
+
+		if _, exist := groupedBySource[key]; !exist {
+			groupedBySource[key] = []*resource.Resource{deletedResource}
+			continue
+		}
+
+		groupedBySource[key] = append(groupedBySource[key], deletedResource)
+	}
+
+	for s := range groupedBySource {
+		sources = append(sources, s)
+	}
+	sort.Strings(sources)
+
+	for _, source := range sources {
+		indentBase := indent
+		if source != "" {
+			fmt.Print(color.BlueString("%sFrom %s\n", indentBase, source))
+			indentBase += "  "
+		}
+		for _, deletedResource := range groupedBySource[source] {
+			humanStringSource := deletedResource.ResourceType()
+			if deletedResource.SourceString() != "" {
+				humanStringSource = deletedResource.SourceString()
+			}
+			humanString := fmt.Sprintf("%s- %s (%s)", indentBase, deletedResource.ResourceId(), humanStringSource)
+
+			if humanAttrs := formatResourceAttributes(deletedResource); humanAttrs != "" {
+				humanString += fmt.Sprintf("\n%s    %s", indentBase, humanAttrs)
+			}
+			fmt.Println(humanString)
+		}
+	}
+}
+
+// writeDifferences prints changed resources grouped by their IaC source
+func (c *Console) writeDifferences(differences []analyser.Difference, indent string) {
+	var sources []string
+	groupedBySource := make(map[string][]analyser.Difference)
+	for _, difference := range differences {
+		key := ""
+		if difference.Res.Source != nil {
+			key = formatResourceSource(difference.Res.Source)
+		}
+		if _, exist := groupedBySource[key]; !exist {
+			groupedBySource[key] = []analyser.Difference{difference}
+			continue
+		}
+		groupedBySource[key] = append(groupedBySource[key], difference)
+	}
+
+	for s := range groupedBySource {
+		sources = append(sources, s)
+	}
+	sort.Strings(sources)
+
+	for _, source := range sources {
+		indentBase := indent
+		if source != "" {
+			fmt.Print(color.BlueString("%sFrom %s\n", indentBase, source))
+			indentBase += "  "
+		}
+		for _, difference := range groupedBySource[source] {
+			humanStringSource := difference.Res.ResourceType()
+			if difference.Res.SourceString() != "" {
+				humanStringSource = difference.Res.SourceString()
+			}
+			humanString := fmt.Sprintf("%s- %s (%s):", indentBase, difference.Res.ResourceId(), humanStringSource)
+			whiteSpace := indentBase + "    "
+			if humanAttrs := formatResourceAttributes(difference.Res); humanAttrs != "" {
+				humanString += fmt.Sprintf("\n%s%s", whiteSpace, humanAttrs)
+				whiteSpace += "    "
+			}
+			fmt.Println(humanString)
+			for _, change := range difference.Changelog {
+				path := strings.Join(change.Path, ".")
+				pref := fmt.Sprintf("%s %s:", color.YellowString("~"), path)
+				if change.Type == diff.CREATE {
+					pref = fmt.Sprintf("%s %s:", color.GreenString("+"), path)
+				} else if change.Type == diff.DELETE {
+					pref = fmt.Sprintf("%s %s:", color.RedString("-"), path)
+				}
+				if change.Type == diff.UPDATE {
+					if change.JsonString {
+						prefix := "           "
+						fmt.Printf("%s%s\n%s%s\n", whiteSpace, pref, prefix, jsonDiff(change.From, change.To, isatty.IsTerminal(os.Stdout.Fd())))
+						continue
+					}
+				}
+				fmt.Printf("%s%s %s => %s", whiteSpace, pref, prettify(change.From), prettify(change.To))
+				if change.Computed {
+					fmt.Printf(" %s", color.YellowString("(computed)"))
+				}
+				fmt.Printf("\n")
+			}
+		}
+	}
+}
+
 func (c Console) writeSummary(analysis *analyser.Analysis) {
 	boldWriter := color.New(color.Bold)
 	successWriter := color.New(color.Bold, color.FgGreen)
@@ -254,6 +288,83 @@ func groupByType(resources []*resource.Resource) (map[string][]*resource.Resourc
 	return result, keys
 }
 
+// accountScopes are scopes locating resources in an account, and how
+// accounts are named by each cloud provider
+var accountScopes = []struct {
+	scope string
+	name  string
+}{
+	{common.ScopeAWSAccount, "account"},
+	{common.ScopeAzureSubscription, "subscription"},
+	{common.ScopeGCPProject, "project"},
+}
+
+// resourceAccount names the account a resource was found in, when several
+// accounts are scanned
+func resourceAccount(res *resource.Resource) string {
+	for _, a := range accountScopes {
+		if id := res.ScopeValue(a.scope); id != "" {
+			return fmt.Sprintf("%s %s", a.name, id)
+		}
+	}
+	return ""
+}
+
+// groupByAccount groups resources by the account they were found in, when
+// several accounts are scanned. Accounts are keyed by their name and ID.
+// Resources are not grouped when found in a single account, as resources are
+// scoped with their account as soon as accounts are given to scan.
+func groupByAccount(resources []*resource.Resource) (map[string][]*resource.Resource, []string) {
+	result := map[string][]*resource.Resource{}
+	for _, res := range resources {
+		account := resourceAccount(res)
+		result[account] = append(result[account], res)
+	}
+
+	keys := make([]string, 0, len(result))
+	for k := range result {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	if countAccounts(keys) < 2 {
+		return map[string][]*resource.Resource{"": resources}, []string{""}
+	}
+	return result, keys
+}
+
+// countAccounts counts the given accounts, leaving aside resources without
+// account
+func countAccounts(accounts []string) int {
+	count := 0
+	for _, account := range accounts {
+		if account != "" {
+			count++
+		}
+	}
+	return count
+}
+
+// groupDifferencesByAccount is the same as groupByAccount for changed resources
+func groupDifferencesByAccount(differences []analyser.Difference) (map[string][]analyser.Difference, []string) {
+	result := map[string][]analyser.Difference{}
+	for _, difference := range differences {
+		account := resourceAccount(difference.Res)
+		result[account] = append(result[account], difference)
+	}
+
+	keys := make([]string, 0, len(result))
+	for k := range result {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	if countAccounts(keys) < 2 {
+		return map[string][]analyser.Difference{"": differences}, []string{""}
+	}
+	return result, keys
+}
+
 func jsonDiff(a, b interface{}, coloring bool) string {
 	aStr := fmt.Sprintf("%s", a)
 	bStr := fmt.Sprintf("%s", b)
@@ -274,6 +385,15 @@ func jsonDiff(a, b interface{}, coloring bool) string {
 	return diffStr
 }
 
+// formatResourceSource tells apart states of the same backend read for
+// several Terraform workspaces
+func formatResourceSource(source resource.Source) string {
+	if source.Workspace() == "" {
+		return source.Source()
+	}
+	return fmt.Sprintf("%s (workspace %s)", source.Source(), source.Workspace())
+}
+
 func formatResourceAttributes(res *resource.Resource) string {
 	if res.Schema() == nil || res.Schema().HumanReadableAttributesFunc == nil {
 		return ""
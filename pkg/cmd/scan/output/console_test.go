@@ -90,6 +90,12 @@ func TestConsole_Write(t *testing.T) {
 			args:       args{analysis: fakeAnalysisWithoutAttrs()},
 			wantErr:    false,
 		},
+		{
+			name:       "test console output with resources of several accounts",
+			goldenfile: "output_accounts.txt",
+			args:       args{analysis: fakeAnalysisWithAccounts()},
+			wantErr:    false,
+		},
 		{
 			name:       "test console output with drift on computed fields",
 			goldenfile: "output_computed_fields.txt",
@@ -156,3 +162,29 @@ func TestConsole_Write(t *testing.T) {
 		})
 	}
 }
+
+func TestFormatResourceSource(t *testing.T) {
+	source := resource.NewTerraformStateSource("tfstate+s3://bucket/terraform.tfstate", "module", "name")
+	assert.Equal(t, "tfstate+s3://bucket/terraform.tfstate", formatResourceSource(source))
+
+	source.WorkspaceName = "staging"
+	assert.Equal(t, "tfstate+s3://bucket/terraform.tfstate (workspace staging)", formatResourceSource(source))
+}
+
+func TestGroupByAccount_SingleAccount(t *testing.T) {
+	resources := []*resource.Resource{
+		{
+			Id:    "firewall",
+			Type:  "google_compute_firewall",
+			Scope: map[string]string{"project": "project-a"},
+		},
+		{
+			Id:   "bucket",
+			Type: "google_storage_bucket",
+		},
+	}
+
+	grouped, accounts := groupByAccount(resources)
+	assert.Equal(t, []string{""}, accounts)
+	assert.Equal(t, resources, grouped[""])
+}
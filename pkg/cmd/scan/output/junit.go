@@ -0,0 +1,198 @@
+package output
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cloudskiff/driftctl/pkg/analyser"
+	"github.com/cloudskiff/driftctl/pkg/resource"
+	"github.com/r3labs/diff/v2"
+)
+
+const JUnitOutputType = "junit"
+const JUnitOutputExample = "junit://PATH/TO/FILE.xml"
+
+// Failure types, one per drift category
+const (
+	junitFailureUnmanaged = "unmanaged"
+	junitFailureMissing   = "missing"
+	junitFailureChanged   = "changed"
+)
+
+type junitTestSuites struct {
+	XMLName    xml.Name         `xml:"testsuites"`
+	Name       string           `xml:"name,attr"`
+	Tests      int              `xml:"tests,attr"`
+	Failures   int              `xml:"failures,attr"`
+	Properties []junitProperty  `xml:"properties>property"`
+	Suites     []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message  string `xml:"message,attr"`
+	Type     string `xml:"type,attr"`
+	Contents string `xml:",chardata"`
+}
+
+type JUnit struct {
+	path string
+}
+
+func NewJUnit(path string) *JUnit {
+	return &JUnit{path}
+}
+
+func (c *JUnit) Write(analysis *analyser.Analysis) error {
+	file := os.Stdout
+	if !isStdOut(c.path) {
+		f, err := os.OpenFile(c.path, os.O_CREATE|os.O_RDWR|os.O_TRUNC, 0600)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		file = f
+	}
+
+	// Changed resources are also part of managed ones, index their changelog
+	// so they are reported only once. Resources are matched by identity, as
+	// several states may contain a resource with the same type and id.
+	differences := make(map[*resource.Resource]analyser.Difference, len(analysis.Differences()))
+	for _, difference := range analysis.Differences() {
+		differences[difference.Res] = difference
+	}
+
+	testCases := map[string][]junitTestCase{}
+	addTestCase := func(res *resource.Resource, failure *junitFailure) {
+		testCases[res.ResourceType()] = append(testCases[res.ResourceType()], junitTestCase{
+			Name:      res.ResourceId(),
+			Classname: res.ResourceType(),
+			Failure:   failure,
+		})
+	}
+
+	for _, res := range analysis.Managed() {
+		var failure *junitFailure
+		if difference, exist := differences[res]; exist {
+			failure = newJUnitChangedFailure(difference)
+			delete(differences, res)
+		}
+		addTestCase(res, failure)
+	}
+	// Report changed resources that are not part of managed ones, e.g. from a partial analysis
+	for _, difference := range analysis.Differences() {
+		if _, exist := differences[difference.Res]; exist {
+			addTestCase(difference.Res, newJUnitChangedFailure(difference))
+		}
+	}
+	for _, res := range analysis.Unmanaged() {
+		addTestCase(res, &junitFailure{
+			Message: fmt.Sprintf("Resource %s (%s) is not covered by IaC", res.ResourceId(), res.ResourceType()),
+			Type:    junitFailureUnmanaged,
+		})
+	}
+	for _, res := range analysis.Deleted() {
+		addTestCase(res, &junitFailure{
+			Message: fmt.Sprintf("Resource %s (%s) is missing on the cloud provider", res.ResourceId(), res.ResourceType()),
+			Type:    junitFailureMissing,
+		})
+	}
+
+	types := make([]string, 0, len(testCases))
+	for ty := range testCases {
+		types = append(types, ty)
+	}
+	sort.Strings(types)
+
+	output := junitTestSuites{
+		Name:       "driftctl",
+		Properties: junitSummaryProperties(analysis),
+		Suites:     make([]junitTestSuite, 0, len(types)),
+	}
+	for _, ty := range types {
+		suite := junitTestSuite{
+			Name:      ty,
+			Tests:     len(testCases[ty]),
+			TestCases: testCases[ty],
+		}
+		for _, testCase := range suite.TestCases {
+			if testCase.Failure != nil {
+				suite.Failures++
+			}
+		}
+		output.Tests += suite.Tests
+		output.Failures += suite.Failures
+		output.Suites = append(output.Suites, suite)
+	}
+
+	content, err := xml.MarshalIndent(output, "", "\t")
+	if err != nil {
+		return err
+	}
+	if _, err := file.WriteString(xml.Header); err != nil {
+		return err
+	}
+	if _, err := file.Write(content); err != nil {
+		return err
+	}
+	return nil
+}
+
+func junitSummaryProperties(analysis *analyser.Analysis) []junitProperty {
+	summary := analysis.Summary()
+	return []junitProperty{
+		{Name: "coverage", Value: strconv.Itoa(analysis.Coverage())},
+		{Name: "total_resources", Value: strconv.Itoa(summary.TotalResources)},
+		{Name: "total_managed", Value: strconv.Itoa(summary.TotalManaged)},
+		{Name: "total_unmanaged", Value: strconv.Itoa(summary.TotalUnmanaged)},
+		{Name: "total_missing", Value: strconv.Itoa(summary.TotalDeleted)},
+		{Name: "total_changed", Value: strconv.Itoa(summary.TotalDrifted)},
+	}
+}
+
+func newJUnitChangedFailure(difference analyser.Difference) *junitFailure {
+	return &junitFailure{
+		Message:  fmt.Sprintf("Resource %s (%s) has changed", difference.Res.ResourceId(), difference.Res.ResourceType()),
+		Type:     junitFailureChanged,
+		Contents: formatJUnitChangelog(difference.Changelog),
+	}
+}
+
+func formatJUnitChangelog(changelog analyser.Changelog) string {
+	lines := make([]string, 0, len(changelog))
+	for _, change := range changelog {
+		pref := "~"
+		if change.Type == diff.CREATE {
+			pref = "+"
+		} else if change.Type == diff.DELETE {
+			pref = "-"
+		}
+		line := fmt.Sprintf("%s %s: %s => %s", pref, strings.Join(change.Path, "."), prettify(change.From), prettify(change.To))
+		if change.Computed {
+			line += " (computed)"
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
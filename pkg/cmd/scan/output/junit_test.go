@@ -0,0 +1,118 @@
+package output
+
+import (
+	"io/ioutil"
+	"path"
+	"testing"
+
+	"github.com/cloudskiff/driftctl/pkg/analyser"
+	"github.com/cloudskiff/driftctl/pkg/resource"
+	"github.com/cloudskiff/driftctl/test/goldenfile"
+	"github.com/r3labs/diff/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeJUnitAnalysis builds an analysis the way the analyzer does, changed
+// resources being the same objects as the managed ones
+func fakeJUnitAnalysis() *analyser.Analysis {
+	a := analyser.Analysis{}
+	a.AddUnmanaged(
+		&resource.Resource{
+			Id:   "unmanaged-id-1",
+			Type: "aws_unmanaged_resource",
+		},
+	)
+	a.AddDeleted(
+		&resource.Resource{
+			Id:     "deleted-id-1",
+			Type:   "aws_deleted_resource",
+			Source: resource.NewTerraformStateSource("tfstate://delete_state.tfstate", "module", "name"),
+		},
+	)
+	changed := &resource.Resource{
+		Id:     "diff-id-1",
+		Type:   "aws_diff_resource",
+		Source: resource.NewTerraformStateSource("tfstate://state.tfstate", "module", "name"),
+	}
+	a.AddManaged(
+		changed,
+		// Same type and id in another state, without any drift
+		&resource.Resource{
+			Id:     "diff-id-1",
+			Type:   "aws_diff_resource",
+			Source: resource.NewTerraformStateSource("tfstate://other.tfstate", "module", "name"),
+		},
+		&resource.Resource{
+			Id:   "no-diff-id-1",
+			Type: "aws_no_diff_resource",
+		},
+	)
+	a.AddDifference(analyser.Difference{
+		Res: changed,
+		Changelog: []analyser.Change{
+			{Change: diff.Change{Type: diff.UPDATE, Path: []string{"updated", "field"}, From: "foobar", To: "barfoo"}},
+			{Change: diff.Change{Type: diff.CREATE, Path: []string{"new", "field"}, To: "newValue"}},
+		},
+	})
+	// Cover the case when a changed resource is not part of managed ones
+	a.AddDifference(analyser.Difference{
+		Res: &resource.Resource{
+			Id:   "diff-id-2",
+			Type: "aws_diff_resource",
+		},
+		Changelog: []analyser.Change{
+			{Change: diff.Change{Type: diff.DELETE, Path: []string{"a"}, From: "oldValue"}},
+		},
+	})
+	return &a
+}
+
+func TestJUnit_Write(t *testing.T) {
+	tests := []struct {
+		name       string
+		goldenfile string
+		analysis   func() *analyser.Analysis
+		wantErr    bool
+	}{
+		{
+			name:       "test junit output",
+			goldenfile: "output_junit.xml",
+			analysis:   fakeJUnitAnalysis,
+			wantErr:    false,
+		},
+		{
+			name:       "test junit output when no infra",
+			goldenfile: "output_empty_junit.xml",
+			analysis:   func() *analyser.Analysis { return &analyser.Analysis{} },
+			wantErr:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			tempFile, err := ioutil.TempFile(tempDir, "result")
+			if err != nil {
+				t.Fatal(err)
+			}
+			c := NewJUnit(tempFile.Name())
+			if err := c.Write(tt.analysis()); (err != nil) != tt.wantErr {
+				t.Errorf("Write() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			result, err := ioutil.ReadFile(tempFile.Name())
+			if err != nil {
+				t.Fatal(err)
+			}
+			expectedFilePath := path.Join("./testdata/", tt.goldenfile)
+			if *goldenfile.Update == tt.goldenfile {
+				if err := ioutil.WriteFile(expectedFilePath, result, 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			expected, err := ioutil.ReadFile(expectedFilePath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, string(expected), string(result))
+		})
+	}
+}
@@ -0,0 +1,213 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/cloudskiff/driftctl/pkg/analyser"
+	"github.com/cloudskiff/driftctl/pkg/resource"
+	"github.com/r3labs/diff/v2"
+)
+
+const MarkdownOutputType = "markdown"
+const MarkdownOutputExample = "markdown://PATH/TO/FILE.md"
+
+// MarkdownMaxSizeOption caps the size of the report in bytes, e.g.
+// markdown://PATH/TO/FILE.md?max_size=16384
+const MarkdownMaxSizeOption = "max_size"
+
+// MarkdownDefaultMaxSize matches the body size limit of GitHub comments
+const MarkdownDefaultMaxSize = 65536
+
+// MarkdownMinMaxSize leaves room for the summary of the report
+const MarkdownMinMaxSize = 1024
+
+// markdownReservedSize is kept free to close an open section and write the
+// truncation notice
+const markdownReservedSize = 512
+
+type Markdown struct {
+	path    string
+	maxSize int
+}
+
+func NewMarkdown(path string, maxSize int) *Markdown {
+	return &Markdown{path, maxSize}
+}
+
+// markdownBuilder stops accepting items once the size cap is reached and
+// counts the ones that were left out
+type markdownBuilder struct {
+	strings.Builder
+	maxSize   int
+	truncated int
+}
+
+func (b *markdownBuilder) writeItem(item string) {
+	if b.truncated > 0 || b.Len()+len(item)+markdownReservedSize > b.maxSize {
+		b.truncated++
+		return
+	}
+	b.WriteString(item)
+}
+
+// writeAccountHeader starts the list of resources of an account, resources of
+// an unknown account are listed first without header
+func (b *markdownBuilder) writeAccountHeader(account string) {
+	if account == "" {
+		return
+	}
+	header := fmt.Sprintf("**In %s**\n\n", account)
+	// Separate the header from the list of the previous account
+	if !strings.HasSuffix(b.String(), "\n\n") {
+		header = "\n" + header
+	}
+	b.writeItem(header)
+}
+
+func (c *Markdown) Write(analysis *analyser.Analysis) error {
+	file := os.Stdout
+	if !isStdOut(c.path) {
+		f, err := os.OpenFile(c.path, os.O_CREATE|os.O_RDWR|os.O_TRUNC, 0600)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		file = f
+	}
+
+	b := &markdownBuilder{maxSize: c.maxSize}
+	c.writeSummary(b, analysis)
+
+	if analysis.Summary().TotalUnmanaged > 0 {
+		b.WriteString(markdownDetailsHeader("Resources not covered by IaC", analysis.Summary().TotalUnmanaged))
+		unmanagedByAccount, accounts := groupByAccount(analysis.Unmanaged())
+		for _, account := range accounts {
+			b.writeAccountHeader(account)
+			unmanagedByType, keys := groupByType(unmanagedByAccount[account])
+			for _, ty := range keys {
+				for _, res := range unmanagedByType[ty] {
+					b.writeItem(markdownResourceItem(res, ty))
+				}
+			}
+		}
+		b.WriteString(markdownDetailsFooter)
+	}
+
+	if analysis.Summary().TotalDeleted > 0 {
+		b.WriteString(markdownDetailsHeader("Missing resources", analysis.Summary().TotalDeleted))
+		deletedByAccount, accounts := groupByAccount(analysis.Deleted())
+		for _, account := range accounts {
+			b.writeAccountHeader(account)
+			for _, res := range deletedByAccount[account] {
+				b.writeItem(markdownResourceItem(res, markdownResourceSource(res)))
+			}
+		}
+		b.WriteString(markdownDetailsFooter)
+	}
+
+	if analysis.Summary().TotalDrifted > 0 {
+		b.WriteString(markdownDetailsHeader("Changed resources", analysis.Summary().TotalDrifted))
+		differencesByAccount, accounts := groupDifferencesByAccount(analysis.Differences())
+		for _, account := range accounts {
+			b.writeAccountHeader(account)
+			for _, difference := range differencesByAccount[account] {
+				b.writeItem(markdownDifferenceItem(difference))
+			}
+		}
+		b.WriteString(markdownDetailsFooter)
+	}
+
+	if len(analysis.Alerts()) > 0 {
+		keys := make([]string, 0, len(analysis.Alerts()))
+		for k := range analysis.Alerts() {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		b.WriteString("#### Alerts\n\n")
+		for _, k := range keys {
+			for _, alert := range analysis.Alerts()[k] {
+				b.writeItem(fmt.Sprintf("- %s\n", alert.Message()))
+			}
+		}
+		b.WriteString("\n")
+	}
+
+	if b.truncated > 0 {
+		b.WriteString(fmt.Sprintf("> :warning: This report has been truncated, %d item(s) are not displayed. Use another output to get the full report.\n", b.truncated))
+	}
+
+	if _, err := file.WriteString(b.String()); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *Markdown) writeSummary(b *markdownBuilder, analysis *analyser.Analysis) {
+	summary := analysis.Summary()
+
+	b.WriteString("### driftctl scan report\n\n")
+	if analysis.IsSync() {
+		b.WriteString(":white_check_mark: Congrats! Your infrastructure is fully in sync.\n\n")
+	}
+	b.WriteString("| | Count |\n")
+	b.WriteString("|---|---:|\n")
+	b.WriteString(fmt.Sprintf("| Total resources | %d |\n", summary.TotalResources))
+	b.WriteString(fmt.Sprintf("| Coverage | %d%% |\n", analysis.Coverage()))
+	b.WriteString(fmt.Sprintf("| Managed | %d |\n", summary.TotalManaged))
+	b.WriteString(fmt.Sprintf("| Changed | %d |\n", summary.TotalDrifted))
+	b.WriteString(fmt.Sprintf("| Not covered by IaC | %d |\n", summary.TotalUnmanaged))
+	b.WriteString(fmt.Sprintf("| Missing | %d |\n", summary.TotalDeleted))
+	b.WriteString("\n")
+}
+
+const markdownDetailsFooter = "\n</details>\n\n"
+
+func markdownDetailsHeader(title string, count int) string {
+	return fmt.Sprintf("<details>\n<summary>%s (%d)</summary>\n\n", title, count)
+}
+
+func markdownResourceSource(res *resource.Resource) string {
+	if res.SourceString() == "" {
+		return res.ResourceType()
+	}
+	if res.Source.Workspace() != "" {
+		return fmt.Sprintf("%s, workspace %s", res.SourceString(), res.Source.Workspace())
+	}
+	return res.SourceString()
+}
+
+func markdownResourceItem(res *resource.Resource, context string) string {
+	item := fmt.Sprintf("- `%s` (%s)\n", res.ResourceId(), context)
+	if humanAttrs := formatResourceAttributes(res); humanAttrs != "" {
+		item += fmt.Sprintf("  - %s\n", humanAttrs)
+	}
+	return item
+}
+
+func markdownDifferenceItem(difference analyser.Difference) string {
+	var item strings.Builder
+	item.WriteString(markdownResourceItem(difference.Res, markdownResourceSource(difference.Res)))
+	item.WriteString("\n```diff\n")
+	for _, change := range difference.Changelog {
+		path := strings.Join(change.Path, ".")
+		computed := ""
+		if change.Computed {
+			computed = " (computed)"
+		}
+		switch change.Type {
+		case diff.CREATE:
+			item.WriteString(fmt.Sprintf("+ %s: %s%s\n", path, prettify(change.To), computed))
+		case diff.DELETE:
+			item.WriteString(fmt.Sprintf("- %s: %s%s\n", path, prettify(change.From), computed))
+		default:
+			item.WriteString(fmt.Sprintf("- %s: %s%s\n", path, prettify(change.From), computed))
+			item.WriteString(fmt.Sprintf("+ %s: %s%s\n", path, prettify(change.To), computed))
+		}
+	}
+	item.WriteString("```\n\n")
+	return item.String()
+}
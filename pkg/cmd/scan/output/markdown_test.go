@@ -0,0 +1,113 @@
+package output
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path"
+	"testing"
+
+	"github.com/cloudskiff/driftctl/pkg/analyser"
+	"github.com/cloudskiff/driftctl/pkg/resource"
+	"github.com/cloudskiff/driftctl/test/goldenfile"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarkdown_Write(t *testing.T) {
+	tests := []struct {
+		name       string
+		goldenfile string
+		analysis   func() *analyser.Analysis
+		wantErr    bool
+	}{
+		{
+			name:       "test markdown output",
+			goldenfile: "output.md",
+			analysis: func() *analyser.Analysis {
+				a := fakeAnalysisWithAlerts()
+				a.Unmanaged()[0].Sch = &resource.Schema{
+					HumanReadableAttributesFunc: func(res *resource.Resource) map[string]string {
+						return map[string]string{"Name": "First unmanaged resource"}
+					},
+				}
+				source := resource.NewTerraformStateSource("tfstate+s3://bucket/terraform.tfstate", "", "workspace")
+				source.WorkspaceName = "staging"
+				a.AddDeleted(&resource.Resource{
+					Id:     "deleted-id-3",
+					Type:   "aws_deleted_resource",
+					Source: source,
+				})
+				return a
+			},
+			wantErr: false,
+		},
+		{
+			name:       "test markdown output with resources of several accounts",
+			goldenfile: "output_accounts.md",
+			analysis:   fakeAnalysisWithAccounts,
+			wantErr:    false,
+		},
+		{
+			name:       "test markdown output when no infra",
+			goldenfile: "output_empty.md",
+			analysis:   func() *analyser.Analysis { return &analyser.Analysis{} },
+			wantErr:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			tempFile, err := ioutil.TempFile(tempDir, "result")
+			if err != nil {
+				t.Fatal(err)
+			}
+			c := NewMarkdown(tempFile.Name(), MarkdownDefaultMaxSize)
+			if err := c.Write(tt.analysis()); (err != nil) != tt.wantErr {
+				t.Errorf("Write() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			result, err := ioutil.ReadFile(tempFile.Name())
+			if err != nil {
+				t.Fatal(err)
+			}
+			expectedFilePath := path.Join("./testdata/", tt.goldenfile)
+			if *goldenfile.Update == tt.goldenfile {
+				if err := ioutil.WriteFile(expectedFilePath, result, 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			expected, err := ioutil.ReadFile(expectedFilePath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, string(expected), string(result))
+		})
+	}
+}
+
+func TestMarkdown_Write_Truncated(t *testing.T) {
+	a := &analyser.Analysis{}
+	for i := 0; i < 100; i++ {
+		a.AddUnmanaged(&resource.Resource{
+			Id:   fmt.Sprintf("unmanaged-id-%d", i),
+			Type: "aws_unmanaged_resource",
+		})
+	}
+
+	tempFile := path.Join(t.TempDir(), "result.md")
+	c := GetOutput(OutputConfig{
+		Key:     MarkdownOutputType,
+		Path:    tempFile,
+		Options: map[string]string{MarkdownMaxSizeOption: "2048"},
+	}).(*Markdown)
+	assert.Equal(t, 2048, c.maxSize)
+	assert.Nil(t, c.Write(a))
+
+	result, err := ioutil.ReadFile(tempFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.LessOrEqual(t, len(result), c.maxSize)
+	assert.Contains(t, string(result), "- `unmanaged-id-0` (aws_unmanaged_resource)\n")
+	assert.NotContains(t, string(result), "unmanaged-id-99")
+	assert.Contains(t, string(result), "</details>")
+	assert.Regexp(t, `This report has been truncated, \d+ item\(s\) are not displayed`, string(result))
+}
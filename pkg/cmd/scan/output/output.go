@@ -2,6 +2,7 @@ package output
 
 import (
 	"sort"
+	"strconv"
 
 	"github.com/cloudskiff/driftctl/pkg/analyser"
 	"github.com/cloudskiff/driftctl/pkg/output"
@@ -16,13 +17,19 @@ var supportedOutputTypes = []string{
 	JSONOutputType,
 	HTMLOutputType,
 	PlanOutputType,
+	SARIFOutputType,
+	JUnitOutputType,
+	MarkdownOutputType,
 }
 
 var supportedOutputExample = map[string]string{
-	ConsoleOutputType: ConsoleOutputExample,
-	JSONOutputType:    JSONOutputExample,
-	HTMLOutputType:    HTMLOutputExample,
-	PlanOutputType:    PlanOutputExample,
+	ConsoleOutputType:  ConsoleOutputExample,
+	JSONOutputType:     JSONOutputExample,
+	HTMLOutputType:     HTMLOutputExample,
+	PlanOutputType:     PlanOutputExample,
+	SARIFOutputType:    SARIFOutputExample,
+	JUnitOutputType:    JUnitOutputExample,
+	MarkdownOutputType: MarkdownOutputExample,
 }
 
 func SupportedOutputsExample() []string {
@@ -55,6 +62,16 @@ func GetOutput(config OutputConfig) Output {
 		return NewHTML(config.Path)
 	case PlanOutputType:
 		return NewPlan(config.Path)
+	case SARIFOutputType:
+		return NewSARIF(config.Path)
+	case JUnitOutputType:
+		return NewJUnit(config.Path)
+	case MarkdownOutputType:
+		maxSize := MarkdownDefaultMaxSize
+		if size, err := strconv.Atoi(config.Options[MarkdownMaxSizeOption]); err == nil {
+			maxSize = size
+		}
+		return NewMarkdown(config.Path, maxSize)
 	case ConsoleOutputType:
 		fallthrough
 	default:
@@ -79,6 +96,11 @@ func GetPrinter(config OutputConfig, quiet bool) output.Printer {
 	}
 
 	switch config.Key {
+	case SARIFOutputType, JUnitOutputType, MarkdownOutputType:
+		if isStdOut(config.Path) {
+			return &output.VoidPrinter{}
+		}
+		fallthrough
 	case JSONOutputType:
 		if isStdOut(config.Path) {
 			return &output.VoidPrinter{}
@@ -229,6 +229,112 @@ func fakeAnalysisWithoutAttrs() *analyser.Analysis {
 	return &a
 }
 
+func fakeAnalysisWithAccounts() *analyser.Analysis {
+	a := analyser.Analysis{}
+	a.AddManaged(
+		&resource.Resource{
+			Id:    "vpc-managed",
+			Type:  "aws_vpc",
+			Scope: map[string]string{"account_id": "111111111111"},
+		},
+		&resource.Resource{
+			Id:     "vpc-changed",
+			Type:   "aws_vpc",
+			Source: resource.NewTerraformStateSource("tfstate://b.tfstate", "", "changed"),
+			Scope:  map[string]string{"account_id": "222222222222"},
+		},
+		&resource.Resource{
+			Id:     "sg-changed",
+			Type:   "aws_security_group",
+			Source: resource.NewTerraformStateSource("tfstate://a.tfstate", "", "changed"),
+			Scope:  map[string]string{"account_id": "111111111111"},
+		},
+	)
+	a.AddUnmanaged(
+		&resource.Resource{
+			Id:    "vpc-2",
+			Type:  "aws_vpc",
+			Scope: map[string]string{"account_id": "222222222222"},
+		},
+		&resource.Resource{
+			Id:    "bucket-1",
+			Type:  "aws_s3_bucket",
+			Scope: map[string]string{"account_id": "111111111111", "region": "eu-west-3"},
+		},
+		&resource.Resource{
+			Id:    "vpc-1",
+			Type:  "aws_vpc",
+			Scope: map[string]string{"account_id": "111111111111"},
+		},
+		&resource.Resource{
+			Id:   "user",
+			Type: "aws_iam_user",
+		},
+		&resource.Resource{
+			Id:    "bucket-a",
+			Type:  "google_storage_bucket",
+			Scope: map[string]string{"project": "project-a"},
+		},
+		&resource.Resource{
+			Id:    "bucket-b",
+			Type:  "google_storage_bucket",
+			Scope: map[string]string{"project": "project-b"},
+		},
+		&resource.Resource{
+			Id:    "/subscriptions/008b5f48-1b66-4d92-a6b6-d215b4c9b473/resourceGroups/driftctl",
+			Type:  "azurerm_resource_group",
+			Scope: map[string]string{"subscription_id": "008b5f48-1b66-4d92-a6b6-d215b4c9b473"},
+		},
+	)
+	a.AddDeleted(
+		&resource.Resource{
+			Id:     "vpc-deleted",
+			Type:   "aws_vpc",
+			Source: resource.NewTerraformStateSource("tfstate://a.tfstate", "", "deleted"),
+			Scope:  map[string]string{"account_id": "111111111111"},
+		},
+		&resource.Resource{
+			Id:     "vpc-unknown",
+			Type:   "aws_vpc",
+			Source: resource.NewTerraformStateSource("tfstate://a.tfstate", "", "unknown"),
+		},
+		&resource.Resource{
+			Id:     "vpc-deleted-2",
+			Type:   "aws_vpc",
+			Source: resource.NewTerraformStateSource("tfstate://b.tfstate", "", "deleted"),
+			Scope:  map[string]string{"account_id": "222222222222"},
+		},
+	)
+	a.AddDifference(analyser.Difference{
+		Res: a.Managed()[1],
+		Changelog: []analyser.Change{
+			{
+				Change: diff.Change{
+					Type: diff.UPDATE,
+					Path: []string{"cidr_block"},
+					From: "10.0.0.0/16",
+					To:   "10.1.0.0/16",
+				},
+			},
+		},
+	})
+	a.AddDifference(analyser.Difference{
+		Res: a.Managed()[2],
+		Changelog: []analyser.Change{
+			{
+				Change: diff.Change{
+					Type: diff.DELETE,
+					Path: []string{"description"},
+					From: "managed by terraform",
+				},
+			},
+		},
+	})
+	a.ProviderName = "AWS"
+	a.ProviderVersion = "3.19.0"
+	return &a
+}
+
 func fakeAnalysisWithStringerResources() *analyser.Analysis {
 	a := analyser.Analysis{}
 	schema := &resource.Schema{HumanReadableAttributesFunc: func(res *resource.Resource) map[string]string {
@@ -6,6 +6,7 @@ import (
 	"os"
 
 	"github.com/cloudskiff/driftctl/pkg/analyser"
+	"github.com/cloudskiff/driftctl/pkg/iac/terraform/plan"
 	"github.com/cloudskiff/driftctl/pkg/resource"
 )
 
@@ -13,40 +14,6 @@ const FormatVersion = "0.1"
 const PlanOutputType = "plan"
 const PlanOutputExample = "plan://PATH/TO/FILE.json"
 
-type plan struct {
-	FormatVersion   string        `json:"format_version,omitempty"`
-	PlannedValues   plannedValues `json:"planned_values,omitempty"`
-	ResourceChanges []rscChange   `json:"resource_changes,omitempty"`
-}
-
-type plannedValues struct {
-	RootModule module `json:"root_module,omitempty"`
-}
-
-type rscChange struct {
-	Address string `json:"address,omitempty"`
-	Type    string `json:"type,omitempty"`
-	Name    string `json:"name,omitempty"`
-	Change  change `json:"change,omitempty"`
-}
-
-type change struct {
-	Actions []string               `json:"actions,omitempty"`
-	Before  map[string]interface{} `json:"before,omitempty"`
-	After   map[string]interface{} `json:"after,omitempty"`
-}
-
-type module struct {
-	Resources []rsc `json:"resources,omitempty"`
-}
-
-type rsc struct {
-	Address         string                 `json:"address,omitempty"`
-	Type            string                 `json:"type,omitempty"`
-	Name            string                 `json:"name,omitempty"`
-	AttributeValues map[string]interface{} `json:"values,omitempty"`
-}
-
 type Plan struct {
 	path string
 }
@@ -65,7 +32,7 @@ func (c *Plan) Write(analysis *analyser.Analysis) error {
 		defer f.Close()
 		file = f
 	}
-	output := plan{FormatVersion: FormatVersion}
+	output := plan.Plan{FormatVersion: FormatVersion}
 	output.PlannedValues.RootModule = addPlannedValues(analysis)
 	output.ResourceChanges = addResourceChanges(analysis)
 	jsonPlan, err := json.MarshalIndent(output, "", "\t")
@@ -78,18 +45,18 @@ func (c *Plan) Write(analysis *analyser.Analysis) error {
 	return nil
 }
 
-func addPlannedValues(analysis *analyser.Analysis) module {
+func addPlannedValues(analysis *analyser.Analysis) plan.Module {
 	managedRsc := listRsc(analysis.Managed())
 	unmanagedRsc := listRsc(analysis.Unmanaged())
-	return module{
+	return plan.Module{
 		Resources: append(managedRsc, unmanagedRsc...),
 	}
 }
 
-func listRsc(resources []*resource.Resource) []rsc {
-	var ret []rsc
+func listRsc(resources []*resource.Resource) []plan.Resource {
+	var ret []plan.Resource
 	for _, res := range resources {
-		r := rsc{
+		r := plan.Resource{
 			Address:         fmt.Sprintf("%s.%s", res.ResourceType(), res.ResourceId()),
 			Type:            res.ResourceType(),
 			Name:            res.ResourceId(),
@@ -100,25 +67,25 @@ func listRsc(resources []*resource.Resource) []rsc {
 	return ret
 }
 
-func addResourceChanges(analysis *analyser.Analysis) []rscChange {
-	managedRsc := listRscChange(analysis.Managed(), "no-op")
-	unmanagedRsc := listRscChange(analysis.Unmanaged(), "create")
+func addResourceChanges(analysis *analyser.Analysis) []plan.ResourceChange {
+	managedRsc := listRscChange(analysis.Managed(), plan.ActionNoop)
+	unmanagedRsc := listRscChange(analysis.Unmanaged(), plan.ActionCreate)
 	return append(managedRsc, unmanagedRsc...)
 }
 
-func listRscChange(resources []*resource.Resource, action string) []rscChange {
-	var ret []rscChange
+func listRscChange(resources []*resource.Resource, action string) []plan.ResourceChange {
+	var ret []plan.ResourceChange
 	for _, res := range resources {
-		r := rscChange{
+		r := plan.ResourceChange{
 			Address: fmt.Sprintf("%s.%s", res.ResourceType(), res.ResourceId()),
 			Type:    res.ResourceType(),
 			Name:    res.ResourceId(),
-			Change: change{
+			Change: plan.Change{
 				Actions: []string{action},
 				After:   *res.Attributes(),
 			},
 		}
-		if action == "no-op" {
+		if action == plan.ActionNoop {
 			r.Change.Before = *res.Attributes()
 		}
 		ret = append(ret, r)
@@ -0,0 +1,311 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/cloudskiff/driftctl/pkg/analyser"
+	"github.com/cloudskiff/driftctl/pkg/resource"
+	"github.com/cloudskiff/driftctl/pkg/version"
+)
+
+const SARIFOutputType = "sarif"
+const SARIFOutputExample = "sarif://PATH/TO/FILE.sarif"
+
+const sarifVersion = "2.1.0"
+const sarifSchema = "https://json.schemastore.org/sarif-2.1.0.json"
+
+// sarifDefaultArtifact locates results that cannot be located in a file of
+// the repository, like unmanaged resources or resources of remote states.
+// GitHub code scanning rejects results without a file location.
+const sarifDefaultArtifact = ".driftignore"
+
+// sarifSourceRoot is the base of artifact locations, relative to the root of
+// the repository
+const sarifSourceRoot = "%SRCROOT%"
+
+// Rules ids, one per drift category
+const (
+	sarifRuleUnmanaged = "unmanaged-resource"
+	sarifRuleMissing   = "missing-resource"
+	sarifRuleChanged   = "changed-resource"
+)
+
+var sarifRules = []sarifRule{
+	{
+		Id:                   sarifRuleUnmanaged,
+		Name:                 "UnmanagedResource",
+		ShortDescription:     sarifMessage{Text: "Resource not covered by IaC"},
+		FullDescription:      sarifMessage{Text: "The resource exists on the cloud provider but is not managed by any IaC source."},
+		DefaultConfiguration: sarifRuleConfiguration{Level: "warning"},
+	},
+	{
+		Id:                   sarifRuleMissing,
+		Name:                 "MissingResource",
+		ShortDescription:     sarifMessage{Text: "Missing resource"},
+		FullDescription:      sarifMessage{Text: "The resource is declared in IaC but does not exist on the cloud provider."},
+		DefaultConfiguration: sarifRuleConfiguration{Level: "error"},
+	},
+	{
+		Id:                   sarifRuleChanged,
+		Name:                 "ChangedResource",
+		ShortDescription:     sarifMessage{Text: "Changed resource"},
+		FullDescription:      sarifMessage{Text: "The resource attributes on the cloud provider differ from the IaC ones."},
+		DefaultConfiguration: sarifRuleConfiguration{Level: "error"},
+	},
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool        sarifTool         `json:"tool"`
+	Invocations []sarifInvocation `json:"invocations"`
+	Results     []sarifResult     `json:"results"`
+	Properties  map[string]int    `json:"properties,omitempty"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version"`
+	InformationUri string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	Id                   string                 `json:"id"`
+	Name                 string                 `json:"name"`
+	ShortDescription     sarifMessage           `json:"shortDescription"`
+	FullDescription      sarifMessage           `json:"fullDescription"`
+	DefaultConfiguration sarifRuleConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifRuleConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifInvocation struct {
+	ExecutionSuccessful        bool                `json:"executionSuccessful"`
+	ToolExecutionNotifications []sarifNotification `json:"toolExecutionNotifications,omitempty"`
+}
+
+type sarifNotification struct {
+	Level      string            `json:"level"`
+	Message    sarifMessage      `json:"message"`
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+type sarifResult struct {
+	RuleId     string                 `json:"ruleId"`
+	RuleIndex  int                    `json:"ruleIndex"`
+	Level      string                 `json:"level"`
+	Message    sarifMessage           `json:"message"`
+	Locations  []sarifLocation        `json:"locations"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	Uri       string `json:"uri"`
+	UriBaseId string `json:"uriBaseId"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+type sarifLogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+type SARIF struct {
+	path string
+}
+
+func NewSARIF(path string) *SARIF {
+	return &SARIF{path}
+}
+
+func (c *SARIF) Write(analysis *analyser.Analysis) error {
+	file := os.Stdout
+	if !isStdOut(c.path) {
+		f, err := os.OpenFile(c.path, os.O_CREATE|os.O_RDWR|os.O_TRUNC, 0600)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		file = f
+	}
+
+	run := sarifRun{
+		Tool: sarifTool{
+			Driver: sarifDriver{
+				Name:           "driftctl",
+				Version:        version.Current(),
+				InformationUri: "https://driftctl.com",
+				Rules:          sarifRules,
+			},
+		},
+		Invocations: []sarifInvocation{
+			{
+				ExecutionSuccessful:        true,
+				ToolExecutionNotifications: sarifNotifications(analysis),
+			},
+		},
+		Results: make([]sarifResult, 0, len(analysis.Unmanaged())+len(analysis.Deleted())+len(analysis.Differences())),
+		Properties: map[string]int{
+			"coverage":        analysis.Coverage(),
+			"total_resources": analysis.Summary().TotalResources,
+			"total_managed":   analysis.Summary().TotalManaged,
+		},
+	}
+
+	for _, res := range analysis.Unmanaged() {
+		run.Results = append(run.Results, newSARIFResult(sarifRuleUnmanaged, res,
+			fmt.Sprintf("Resource %s (%s) is not covered by IaC", res.ResourceId(), res.ResourceType()),
+		))
+	}
+	for _, res := range analysis.Deleted() {
+		run.Results = append(run.Results, newSARIFResult(sarifRuleMissing, res,
+			fmt.Sprintf("Resource %s (%s) is missing on the cloud provider", res.ResourceId(), res.ResourceType()),
+		))
+	}
+	for _, difference := range analysis.Differences() {
+		paths := make([]string, 0, len(difference.Changelog))
+		for _, change := range difference.Changelog {
+			paths = append(paths, strings.Join(change.Path, "."))
+		}
+		result := newSARIFResult(sarifRuleChanged, difference.Res,
+			fmt.Sprintf("Resource %s (%s) has changed: %s", difference.Res.ResourceId(), difference.Res.ResourceType(), strings.Join(paths, ", ")),
+		)
+		if result.Properties == nil {
+			result.Properties = map[string]interface{}{}
+		}
+		result.Properties["changelog"] = difference.Changelog
+		run.Results = append(run.Results, result)
+	}
+
+	output := sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []sarifRun{run},
+	}
+
+	content, err := json.MarshalIndent(output, "", "\t")
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(content); err != nil {
+		return err
+	}
+	return nil
+}
+
+func newSARIFResult(ruleId string, res *resource.Resource, message string) sarifResult {
+	ruleIndex := 0
+	for i, rule := range sarifRules {
+		if rule.Id == ruleId {
+			ruleIndex = i
+			break
+		}
+	}
+
+	location := sarifLocation{
+		PhysicalLocation: &sarifPhysicalLocation{
+			ArtifactLocation: sarifArtifactLocation{Uri: sarifDefaultArtifact, UriBaseId: sarifSourceRoot},
+			Region:           sarifRegion{StartLine: 1},
+		},
+		LogicalLocations: []sarifLogicalLocation{
+			{
+				Name:               res.ResourceId(),
+				FullyQualifiedName: fmt.Sprintf("%s.%s", res.ResourceType(), res.ResourceId()),
+				Kind:               "resource",
+			},
+		},
+	}
+
+	// Resources coming from IaC are located at their address in the Terraform
+	// state, and in the state file when it is in the repository
+	var properties map[string]interface{}
+	if res.Source != nil {
+		if uri, ok := sarifRepositoryPath(res.Source.Source()); ok {
+			location.PhysicalLocation.ArtifactLocation.Uri = uri
+		}
+		location.LogicalLocations[0].FullyQualifiedName = res.SourceString()
+		properties = map[string]interface{}{"source": res.Source.Source()}
+	}
+
+	return sarifResult{
+		RuleId:     ruleId,
+		RuleIndex:  ruleIndex,
+		Level:      sarifRules[ruleIndex].DefaultConfiguration.Level,
+		Message:    sarifMessage{Text: message},
+		Locations:  []sarifLocation{location},
+		Properties: properties,
+	}
+}
+
+// sarifRepositoryPath returns the path of a local IaC source relative to the
+// repository, e.g. tfstate://states/prod.tfstate is states/prod.tfstate.
+// Sources read through a backend, like tfstate+s3://, are not in the repository.
+func sarifRepositoryPath(source string) (string, bool) {
+	schemePath := strings.SplitN(source, "://", 2)
+	if len(schemePath) != 2 || strings.Contains(schemePath[0], "+") {
+		return "", false
+	}
+	p := filepath.ToSlash(filepath.Clean(schemePath[1]))
+	if p == "." || filepath.IsAbs(schemePath[1]) || p == ".." || strings.HasPrefix(p, "../") {
+		return "", false
+	}
+	return p, true
+}
+
+func sarifNotifications(analysis *analyser.Analysis) []sarifNotification {
+	alerts := analysis.Alerts()
+	keys := make([]string, 0, len(alerts))
+	for key := range alerts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var notifications []sarifNotification
+	for _, key := range keys {
+		for _, alert := range alerts[key] {
+			notification := sarifNotification{
+				Level:   "warning",
+				Message: sarifMessage{Text: alert.Message()},
+			}
+			if key != "" {
+				notification.Properties = map[string]string{"alert_key": key}
+			}
+			notifications = append(notifications, notification)
+		}
+	}
+	return notifications
+}
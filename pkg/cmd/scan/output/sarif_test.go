@@ -0,0 +1,155 @@
+package output
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/cloudskiff/driftctl/pkg/alerter"
+	"github.com/cloudskiff/driftctl/pkg/analyser"
+	"github.com/cloudskiff/driftctl/pkg/resource"
+	"github.com/cloudskiff/driftctl/test/goldenfile"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSARIF_Write(t *testing.T) {
+	tests := []struct {
+		name       string
+		goldenfile string
+		analysis   func() *analyser.Analysis
+		wantErr    bool
+	}{
+		{
+			name:       "test sarif output",
+			goldenfile: "output.sarif",
+			analysis: func() *analyser.Analysis {
+				a := fakeAnalysisWithAlerts()
+				a.Alerts()["aws_s3_bucket_policy"] = []alerter.Alert{
+					&alerter.FakeAlert{Msg: "Resource aws_s3_bucket_policy has been ignored"},
+				}
+				return a
+			},
+			wantErr: false,
+		},
+		{
+			name:       "test sarif output when no infra",
+			goldenfile: "output_empty.sarif",
+			analysis:   func() *analyser.Analysis { return &analyser.Analysis{} },
+			wantErr:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			tempFile, err := ioutil.TempFile(tempDir, "result")
+			if err != nil {
+				t.Fatal(err)
+			}
+			c := NewSARIF(tempFile.Name())
+			if err := c.Write(tt.analysis()); (err != nil) != tt.wantErr {
+				t.Errorf("Write() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			result, err := ioutil.ReadFile(tempFile.Name())
+			if err != nil {
+				t.Fatal(err)
+			}
+			expectedFilePath := path.Join("./testdata/", tt.goldenfile)
+			if *goldenfile.Update == tt.goldenfile {
+				if err := ioutil.WriteFile(expectedFilePath, result, 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			expected, err := ioutil.ReadFile(expectedFilePath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, string(expected), string(result))
+		})
+	}
+}
+
+// GitHub code scanning rejects SARIF files missing any of these fields, see
+// https://docs.github.com/en/code-security/code-scanning/integrating-with-code-scanning/sarif-support-for-code-scanning
+func TestSARIF_GitHubRequiredFields(t *testing.T) {
+	a := fakeAnalysisWithAlerts()
+	a.AddDeleted(
+		&resource.Resource{
+			Id:     "remote-state-id",
+			Type:   "aws_s3_bucket",
+			Source: resource.NewTerraformStateSource("tfstate+s3://bucket/terraform.tfstate", "", "remote"),
+		},
+		&resource.Resource{
+			Id:     "absolute-state-id",
+			Type:   "aws_s3_bucket",
+			Source: resource.NewTerraformStateSource("tfstate:///tmp/terraform.tfstate", "", "absolute"),
+		},
+	)
+
+	tempFile, err := ioutil.TempFile(t.TempDir(), "result")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := NewSARIF(tempFile.Name()).Write(a); err != nil {
+		t.Fatal(err)
+	}
+	content, err := ioutil.ReadFile(tempFile.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(content, &log); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "2.1.0", log.Version)
+	assert.Len(t, log.Runs, 1)
+
+	run := log.Runs[0]
+	assert.NotEmpty(t, run.Tool.Driver.Name)
+	ruleIds := map[string]bool{}
+	for _, rule := range run.Tool.Driver.Rules {
+		assert.NotEmpty(t, rule.Id)
+		ruleIds[rule.Id] = true
+	}
+
+	assert.NotEmpty(t, run.Results)
+	for _, result := range run.Results {
+		assert.True(t, ruleIds[result.RuleId], "unknown rule %s", result.RuleId)
+		assert.NotEmpty(t, result.Message.Text)
+		assert.NotEmpty(t, result.Locations)
+		for _, location := range result.Locations {
+			if !assert.NotNil(t, location.PhysicalLocation, result.Message.Text) {
+				continue
+			}
+			uri := location.PhysicalLocation.ArtifactLocation.Uri
+			assert.NotEmpty(t, uri)
+			assert.NotContains(t, uri, "://", result.Message.Text)
+			assert.False(t, strings.HasPrefix(uri, "/"), "%s is not relative to the repository", uri)
+			assert.GreaterOrEqual(t, location.PhysicalLocation.Region.StartLine, 1)
+		}
+	}
+}
+
+func TestSarifRepositoryPath(t *testing.T) {
+	tests := []struct {
+		source string
+		want   string
+		wantOk bool
+	}{
+		{source: "tfstate://terraform.tfstate", want: "terraform.tfstate", wantOk: true},
+		{source: "tfstate://./states/../prod/terraform.tfstate", want: "prod/terraform.tfstate", wantOk: true},
+		{source: "tfstate:///tmp/terraform.tfstate", wantOk: false},
+		{source: "tfstate://../terraform.tfstate", wantOk: false},
+		{source: "tfstate+s3://bucket/terraform.tfstate", wantOk: false},
+		{source: "terraform.tfstate", wantOk: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			got, ok := sarifRepositoryPath(tt.source)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
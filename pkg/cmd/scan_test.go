@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/cloudskiff/driftctl/pkg"
@@ -10,6 +11,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	"github.com/cloudskiff/driftctl/pkg/resource"
 	"github.com/cloudskiff/driftctl/test"
 
 	"github.com/spf13/cobra"
@@ -42,16 +44,30 @@ func TestScanCmd_Valid(t *testing.T) {
 		{args: []string{"scan", "--to", "aws+tf", "--from", "tfstate://test"}},
 		{args: []string{"scan", "--to", "aws+tf", "--from", "tfstate+https://github.com/state.tfstate"}},
 		{args: []string{"scan", "--to", "aws+tf", "--from", "tfstate+tfcloud://workspace_id"}},
+		{args: []string{"scan", "--from", "tfstate+workdir://."}},
+		{args: []string{"scan", "--from", "pulumi://stack.json", "--from", "pulumi+s3://bucket/.pulumi/stacks/dev.json"}},
+		{args: []string{"scan", "--from", "cloudformation://resources.json", "--from", "cloudformation+stack://legacy-*"}},
+		{args: []string{"scan", "--from", "tfplan://plan.json"}},
 		{args: []string{"scan", "--tfc-token", "token"}},
 		{args: []string{"scan", "--filter", "Type=='aws_s3_bucket'"}},
 		{args: []string{"scan", "--strict"}},
+		{args: []string{"scan", "--to", "aws+tf", "--aws-regions", "us-east-1,eu-west-3"}},
+		{args: []string{"scan", "--to", "aws+tf", "--aws-assume-roles", "arn:aws:iam::123456789012:role/driftctl", "--aws-assume-role-external-id", "id"}},
 		{args: []string{"scan", "--deep"}},
+		{args: []string{"scan", "--only-managed-types"}},
 		{args: []string{"scan", "--tf-provider-version", "1.2.3"}},
 		{args: []string{"scan", "--tf-provider-version", "3.30.2"}},
 		{args: []string{"scan", "--driftignore", "./path/to/driftignore.s3"}},
 		{args: []string{"scan", "--driftignore", ".driftignore"}},
 		{args: []string{"scan", "-o", "html://result.html", "-o", "json://result.json"}},
 		{args: []string{"scan", "--tf-lockfile", "../.terraform.lock.hcl"}},
+		{args: []string{"scan", "-o", "json://result.json", "--json-schema-version", "2"}},
+		{args: []string{"scan", "--min-coverage", "80", "--max-unmanaged", "10", "--max-missing", "0", "--max-changed", "2"}},
+		{args: []string{"scan", "--max-drift-per-type", "aws_s3_bucket=0,aws_iam_user=5"}},
+		{args: []string{"scan", "--history"}},
+		{args: []string{"scan", "--state-cache"}},
+		{args: []string{"scan", "--state-cache", "--state-cache-max-size", "64"}},
+		{args: []string{"scan", "--state-cache", "--no-state-cache"}},
 	}
 
 	for _, tt := range cases {
@@ -65,6 +81,77 @@ func TestScanCmd_Valid(t *testing.T) {
 	}
 }
 
+func TestScanCmd_StateCache(t *testing.T) {
+	cases := []struct {
+		args    []string
+		enabled bool
+	}{
+		{args: []string{"scan"}, enabled: false},
+		{args: []string{"scan", "--state-cache"}, enabled: true},
+		{args: []string{"scan", "--state-cache", "--state-cache-max-size", "64"}, enabled: true},
+		{args: []string{"scan", "--state-cache", "--no-state-cache"}, enabled: false},
+		{args: []string{"scan", "--no-state-cache"}, enabled: false},
+	}
+
+	for _, tt := range cases {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			opts := &pkg.ScanOptions{}
+			rootCmd := &cobra.Command{Use: "root"}
+			scanCmd := NewScanCmd(opts)
+			scanCmd.RunE = func(_ *cobra.Command, args []string) error { return nil }
+			rootCmd.AddCommand(scanCmd)
+
+			_, err := test.Execute(rootCmd, tt.args...)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.enabled, opts.BackendOptions.StateCache != nil)
+		})
+	}
+}
+
+// Slice flags are not reset between runs of a command, so remotes options
+// of other cloud providers are tested with their own command
+func TestScanCmd_ValidGCPScopes(t *testing.T) {
+	rootCmd := &cobra.Command{Use: "root"}
+	scanCmd := NewScanCmd(&pkg.ScanOptions{})
+	scanCmd.RunE = func(_ *cobra.Command, args []string) error { return nil }
+	rootCmd.AddCommand(scanCmd)
+
+	output, err := test.Execute(rootCmd, "scan", "--to", "gcp+tf", "--gcp-scopes", "projects/my-project,folders/123456789")
+	assert.Empty(t, output)
+	assert.NoError(t, err)
+}
+
+func TestScanCmd_ValidAzureSubscriptions(t *testing.T) {
+	rootCmd := &cobra.Command{Use: "root"}
+	scanCmd := NewScanCmd(&pkg.ScanOptions{})
+	scanCmd.RunE = func(_ *cobra.Command, args []string) error { return nil }
+	rootCmd.AddCommand(scanCmd)
+
+	output, err := test.Execute(rootCmd, "scan", "--to", "azure+tf", "--azure-subscriptions", "all")
+	assert.Empty(t, output)
+	assert.NoError(t, err)
+}
+
+func TestScanCmd_ValidOnlyFilteredTypes(t *testing.T) {
+	rootCmd := &cobra.Command{Use: "root"}
+	opts := &pkg.ScanOptions{}
+	scanCmd := NewScanCmd(opts)
+	scanCmd.RunE = func(_ *cobra.Command, args []string) error { return nil }
+	rootCmd.AddCommand(scanCmd)
+
+	output, err := test.Execute(rootCmd, "scan", "--filter", "Type=='aws_route' && Id!='r-1'", "--only-filtered-types")
+	assert.Empty(t, output)
+	assert.NoError(t, err)
+	assert.True(t, opts.OnlyFilteredTypes)
+	assert.Equal(t, []resource.ResourceType{
+		"aws_default_route_table",
+		"aws_default_vpc",
+		"aws_internet_gateway",
+		"aws_route",
+		"aws_route_table",
+	}, opts.ScanTypes)
+}
+
 func TestScanCmd_Invalid(t *testing.T) {
 	cases := []struct {
 		args     []string
@@ -80,20 +167,39 @@ func TestScanCmd_Invalid(t *testing.T) {
 		{args: []string{"scan", "-f"}, expected: `flag needs an argument: 'f' in -f`},
 		{args: []string{"scan", "--from"}, expected: `flag needs an argument: --from`},
 		{args: []string{"scan", "--from"}, expected: `flag needs an argument: --from`},
-		{args: []string{"scan", "--from", "tosdgjhgsdhgkjs"}, expected: "Unable to parse from flag 'tosdgjhgsdhgkjs': \nAccepted schemes are: tfstate://,tfstate+s3://,tfstate+http://,tfstate+https://,tfstate+tfcloud://"},
-		{args: []string{"scan", "--from", "://"}, expected: "Unable to parse from flag '://': \nAccepted schemes are: tfstate://,tfstate+s3://,tfstate+http://,tfstate+https://,tfstate+tfcloud://"},
-		{args: []string{"scan", "--from", "://test"}, expected: "Unable to parse from flag '://test': \nAccepted schemes are: tfstate://,tfstate+s3://,tfstate+http://,tfstate+https://,tfstate+tfcloud://"},
-		{args: []string{"scan", "--from", "tosdgjhgsdhgkjs://"}, expected: "Unable to parse from flag 'tosdgjhgsdhgkjs://': \nAccepted schemes are: tfstate://,tfstate+s3://,tfstate+http://,tfstate+https://,tfstate+tfcloud://"},
-		{args: []string{"scan", "--from", "terraform+foo+bar://test"}, expected: "Unable to parse from scheme 'terraform+foo+bar': \nAccepted schemes are: tfstate://,tfstate+s3://,tfstate+http://,tfstate+https://,tfstate+tfcloud://"},
-		{args: []string{"scan", "--from", "unsupported://test"}, expected: "Unsupported IaC source 'unsupported': \nAccepted values are: tfstate"},
-		{args: []string{"scan", "--from", "tfstate+foobar://test"}, expected: "Unsupported IaC backend 'foobar': \nAccepted values are: s3,http,https,tfcloud"},
-		{args: []string{"scan", "--from", "tfstate:///tmp/test", "--from", "tfstate+toto://test"}, expected: "Unsupported IaC backend 'toto': \nAccepted values are: s3,http,https,tfcloud"},
+		{args: []string{"scan", "--from", "tosdgjhgsdhgkjs"}, expected: "Unable to parse from flag 'tosdgjhgsdhgkjs': \nAccepted schemes are: tfstate://,tfstate+s3://,tfstate+gs://,tfstate+azurerm://,tfstate+http://,tfstate+https://,tfstate+tfcloud://,tfstate+workdir://,pulumi://,pulumi+s3://,pulumi+gs://,pulumi+azurerm://,pulumi+http://,pulumi+https://,cloudformation://,cloudformation+s3://,cloudformation+http://,cloudformation+https://,cloudformation+stack://,tfplan://,tfplan+s3://,tfplan+gs://,tfplan+azurerm://,tfplan+http://,tfplan+https://"},
+		{args: []string{"scan", "--from", "://"}, expected: "Unable to parse from flag '://': \nAccepted schemes are: tfstate://,tfstate+s3://,tfstate+gs://,tfstate+azurerm://,tfstate+http://,tfstate+https://,tfstate+tfcloud://,tfstate+workdir://,pulumi://,pulumi+s3://,pulumi+gs://,pulumi+azurerm://,pulumi+http://,pulumi+https://,cloudformation://,cloudformation+s3://,cloudformation+http://,cloudformation+https://,cloudformation+stack://,tfplan://,tfplan+s3://,tfplan+gs://,tfplan+azurerm://,tfplan+http://,tfplan+https://"},
+		{args: []string{"scan", "--from", "://test"}, expected: "Unable to parse from flag '://test': \nAccepted schemes are: tfstate://,tfstate+s3://,tfstate+gs://,tfstate+azurerm://,tfstate+http://,tfstate+https://,tfstate+tfcloud://,tfstate+workdir://,pulumi://,pulumi+s3://,pulumi+gs://,pulumi+azurerm://,pulumi+http://,pulumi+https://,cloudformation://,cloudformation+s3://,cloudformation+http://,cloudformation+https://,cloudformation+stack://,tfplan://,tfplan+s3://,tfplan+gs://,tfplan+azurerm://,tfplan+http://,tfplan+https://"},
+		{args: []string{"scan", "--from", "tosdgjhgsdhgkjs://"}, expected: "Unable to parse from flag 'tosdgjhgsdhgkjs://': \nAccepted schemes are: tfstate://,tfstate+s3://,tfstate+gs://,tfstate+azurerm://,tfstate+http://,tfstate+https://,tfstate+tfcloud://,tfstate+workdir://,pulumi://,pulumi+s3://,pulumi+gs://,pulumi+azurerm://,pulumi+http://,pulumi+https://,cloudformation://,cloudformation+s3://,cloudformation+http://,cloudformation+https://,cloudformation+stack://,tfplan://,tfplan+s3://,tfplan+gs://,tfplan+azurerm://,tfplan+http://,tfplan+https://"},
+		{args: []string{"scan", "--from", "terraform+foo+bar://test"}, expected: "Unable to parse from scheme 'terraform+foo+bar': \nAccepted schemes are: tfstate://,tfstate+s3://,tfstate+gs://,tfstate+azurerm://,tfstate+http://,tfstate+https://,tfstate+tfcloud://,tfstate+workdir://,pulumi://,pulumi+s3://,pulumi+gs://,pulumi+azurerm://,pulumi+http://,pulumi+https://,cloudformation://,cloudformation+s3://,cloudformation+http://,cloudformation+https://,cloudformation+stack://,tfplan://,tfplan+s3://,tfplan+gs://,tfplan+azurerm://,tfplan+http://,tfplan+https://"},
+		{args: []string{"scan", "--from", "unsupported://test"}, expected: "Unsupported IaC source 'unsupported': \nAccepted values are: tfstate,pulumi,cloudformation,tfplan"},
+		{args: []string{"scan", "--from", "tfstate+foobar://test"}, expected: "Unsupported IaC backend 'foobar': \nAccepted values are: s3,gs,azurerm,http,https,tfcloud,workdir"},
+		{args: []string{"scan", "--from", "tfstate:///tmp/test", "--from", "tfstate+toto://test"}, expected: "Unsupported IaC backend 'toto': \nAccepted values are: s3,gs,azurerm,http,https,tfcloud,workdir"},
+		{args: []string{"scan", "--state-cache", "--state-cache-max-size", "0"}, expected: "State cache max size should be greater than 0"},
+		{args: []string{"scan", "--from", "pulumi+tfcloud://test"}, expected: "Unsupported IaC backend 'tfcloud': \nAccepted values are: s3,gs,azurerm,http,https"},
+		{args: []string{"scan", "--to", "github+tf", "--aws-regions", "us-east-1"}, expected: "AWS regions can only be scanned with the aws+tf cloud provider"},
+		{args: []string{"scan", "--aws-regions", "all,us-east-1"}, expected: "AWS region 'all' cannot be combined with other regions"},
+		{args: []string{"scan", "--to", "github+tf", "--aws-assume-roles", "arn:aws:iam::123456789012:role/driftctl"}, expected: "AWS roles can only be assumed with the aws+tf cloud provider"},
+		{args: []string{"scan", "--aws-assume-roles", "driftctl"}, expected: "Invalid AWS role ARN 'driftctl'"},
+		{args: []string{"scan", "--aws-assume-roles-file", "testdata/missing_roles"}, expected: "unable to read AWS roles file: open testdata/missing_roles: no such file or directory"},
+		{args: []string{"scan", "--gcp-scopes", "projects/my-project"}, expected: "Google Cloud scopes can only be scanned with the gcp+tf cloud provider"},
+		{args: []string{"scan", "--to", "gcp+tf", "--gcp-scopes", "my-project"}, expected: "Invalid Google Cloud scope 'my-project', expected one of projects/,folders/,organizations/ followed by an ID"},
+		{args: []string{"scan", "--to", "gcp+tf", "--gcp-scopes", "folders/"}, expected: "Invalid Google Cloud scope 'folders/', expected one of projects/,folders/,organizations/ followed by an ID"},
+		{args: []string{"scan", "--azure-subscriptions", "all"}, expected: "Azure subscriptions can only be scanned with the azure+tf cloud provider"},
+		{args: []string{"scan", "--to", "azure+tf", "--azure-subscriptions", "all,008b5f48-1b66-4d92-a6b6-d215b4c9b473"}, expected: "Azure subscription 'all' cannot be combined with other subscriptions"},
 		{args: []string{"scan", "--filter", "Type='test'"}, expected: "unable to parse filter expression: SyntaxError: Expected tRbracket, received: tUnknown"},
 		{args: []string{"scan", "--filter", "Type='test'", "--filter", "Type='test2'"}, expected: "Filter flag should be specified only once"},
+		{args: []string{"scan", "--only-filtered-types"}, expected: "A filter expression is required to only scan filtered types"},
+		{args: []string{"scan", "--filter", "Type!='aws_s3_bucket'", "--only-filtered-types"}, expected: "Unable to derive resource types from filter expression, Type should be compared to strings (e.g. Type=='aws_s3_bucket')"},
+		{args: []string{"scan", "--filter", "Type=='aws_s3_buckets'", "--only-filtered-types"}, expected: "Unsupported resource type 'aws_s3_buckets' in filter expression: \nOnly supported resource types can be scanned"},
 		{args: []string{"scan", "--tf-provider-version", ".30.2"}, expected: "Invalid version argument .30.2, expected a valid semver string (e.g. 2.13.4)"},
 		{args: []string{"scan", "--tf-provider-version", "foo"}, expected: "Invalid version argument foo, expected a valid semver string (e.g. 2.13.4)"},
 		{args: []string{"scan", "--driftignore"}, expected: "flag needs an argument: --driftignore"},
 		{args: []string{"scan", "--tf-lockfile"}, expected: "flag needs an argument: --tf-lockfile"},
+		{args: []string{"scan", "--json-schema-version", "3"}, expected: "Invalid json schema version 3, accepted values are: 1,2"},
+		{args: []string{"scan", "--min-coverage", "101"}, expected: "Invalid value 101 for --min-coverage, expected a percentage"},
+		{args: []string{"scan", "--max-unmanaged", "-1"}, expected: "Invalid value -1 for --max-unmanaged, expected a positive number"},
+		{args: []string{"scan", "--max-drift-per-type", "aws_s3_bucket=-1"}, expected: "Invalid value -1 for aws_s3_bucket in --max-drift-per-type, expected a positive number"},
 	}
 
 	for _, tt := range cases {
@@ -183,7 +289,7 @@ func Test_parseOutputFlag(t *testing.T) {
 				out: []string{""},
 			},
 			want: []output.OutputConfig{},
-			err:  fmt.Errorf("Unable to parse output flag '': \nAccepted formats are: console://,html://PATH/TO/FILE.html,json://PATH/TO/FILE.json,plan://PATH/TO/FILE.json"),
+			err:  fmt.Errorf("Unable to parse output flag '': \nAccepted formats are: console://,html://PATH/TO/FILE.html,json://PATH/TO/FILE.json,junit://PATH/TO/FILE.xml,markdown://PATH/TO/FILE.md,plan://PATH/TO/FILE.json,sarif://PATH/TO/FILE.sarif"),
 		},
 		{
 			name: "test empty array",
@@ -199,7 +305,7 @@ func Test_parseOutputFlag(t *testing.T) {
 				out: []string{"sdgjsdgjsdg"},
 			},
 			want: []output.OutputConfig{},
-			err:  fmt.Errorf("Unable to parse output flag 'sdgjsdgjsdg': \nAccepted formats are: console://,html://PATH/TO/FILE.html,json://PATH/TO/FILE.json,plan://PATH/TO/FILE.json"),
+			err:  fmt.Errorf("Unable to parse output flag 'sdgjsdgjsdg': \nAccepted formats are: console://,html://PATH/TO/FILE.html,json://PATH/TO/FILE.json,junit://PATH/TO/FILE.xml,markdown://PATH/TO/FILE.md,plan://PATH/TO/FILE.json,sarif://PATH/TO/FILE.sarif"),
 		},
 		{
 			name: "test invalid",
@@ -207,7 +313,7 @@ func Test_parseOutputFlag(t *testing.T) {
 				out: []string{"://"},
 			},
 			want: []output.OutputConfig{},
-			err:  fmt.Errorf("Unable to parse output flag '://': \nAccepted formats are: console://,html://PATH/TO/FILE.html,json://PATH/TO/FILE.json,plan://PATH/TO/FILE.json"),
+			err:  fmt.Errorf("Unable to parse output flag '://': \nAccepted formats are: console://,html://PATH/TO/FILE.html,json://PATH/TO/FILE.json,junit://PATH/TO/FILE.xml,markdown://PATH/TO/FILE.md,plan://PATH/TO/FILE.json,sarif://PATH/TO/FILE.sarif"),
 		},
 		{
 			name: "test unsupported",
@@ -215,7 +321,7 @@ func Test_parseOutputFlag(t *testing.T) {
 				out: []string{"foobar://"},
 			},
 			want: []output.OutputConfig{},
-			err:  fmt.Errorf("Unsupported output 'foobar': \nValid formats are: console://,html://PATH/TO/FILE.html,json://PATH/TO/FILE.json,plan://PATH/TO/FILE.json"),
+			err:  fmt.Errorf("Unsupported output 'foobar': \nValid formats are: console://,html://PATH/TO/FILE.html,json://PATH/TO/FILE.json,junit://PATH/TO/FILE.xml,markdown://PATH/TO/FILE.md,plan://PATH/TO/FILE.json,sarif://PATH/TO/FILE.sarif"),
 		},
 		{
 			name: "test empty json",
@@ -271,6 +377,115 @@ func Test_parseOutputFlag(t *testing.T) {
 			},
 			err: nil,
 		},
+		{
+			name: "test empty sarif",
+			args: args{
+				out: []string{"sarif://"},
+			},
+			want: []output.OutputConfig{},
+			err:  fmt.Errorf("Invalid sarif output 'sarif://': \nMust be of kind: sarif://PATH/TO/FILE.sarif"),
+		},
+		{
+			name: "test valid sarif",
+			args: args{
+				out: []string{"sarif:///tmp/foobar.sarif"},
+			},
+			want: []output.OutputConfig{
+				{
+					Key:  "sarif",
+					Path: "/tmp/foobar.sarif",
+				},
+			},
+			err: nil,
+		},
+		{
+			name: "test empty junit",
+			args: args{
+				out: []string{"junit://"},
+			},
+			want: []output.OutputConfig{},
+			err:  fmt.Errorf("Invalid junit output 'junit://': \nMust be of kind: junit://PATH/TO/FILE.xml"),
+		},
+		{
+			name: "test valid junit",
+			args: args{
+				out: []string{"junit:///tmp/foobar.xml"},
+			},
+			want: []output.OutputConfig{
+				{
+					Key:  "junit",
+					Path: "/tmp/foobar.xml",
+				},
+			},
+			err: nil,
+		},
+		{
+			name: "test empty markdown",
+			args: args{
+				out: []string{"markdown://"},
+			},
+			want: []output.OutputConfig{},
+			err:  fmt.Errorf("Invalid markdown output 'markdown://': \nMust be of kind: markdown://PATH/TO/FILE.md"),
+		},
+		{
+			name: "test valid markdown",
+			args: args{
+				out: []string{"markdown:///tmp/foobar.md"},
+			},
+			want: []output.OutputConfig{
+				{
+					Key:  "markdown",
+					Path: "/tmp/foobar.md",
+				},
+			},
+			err: nil,
+		},
+		{
+			name: "test valid markdown with max size",
+			args: args{
+				out: []string{"markdown:///tmp/foobar.md?max_size=4096"},
+			},
+			want: []output.OutputConfig{
+				{
+					Key:     "markdown",
+					Path:    "/tmp/foobar.md",
+					Options: map[string]string{"max_size": "4096"},
+				},
+			},
+			err: nil,
+		},
+		{
+			name: "test markdown with invalid max size",
+			args: args{
+				out: []string{"markdown:///tmp/foobar.md?max_size=abc"},
+			},
+			want: []output.OutputConfig{},
+			err:  fmt.Errorf("Invalid markdown output 'markdown:///tmp/foobar.md?max_size=abc': \nMust be of kind: markdown://PATH/TO/FILE.md?max_size=N, with N at least 1024"),
+		},
+		{
+			name: "test markdown with too small max size",
+			args: args{
+				out: []string{"markdown:///tmp/foobar.md?max_size=10"},
+			},
+			want: []output.OutputConfig{},
+			err:  fmt.Errorf("Invalid markdown output 'markdown:///tmp/foobar.md?max_size=10': \nMust be of kind: markdown://PATH/TO/FILE.md?max_size=N, with N at least 1024"),
+		},
+		{
+			name: "test markdown with unknown option",
+			args: args{
+				out: []string{"markdown:///tmp/foobar.md?foo=bar"},
+			},
+			want: []output.OutputConfig{},
+			err:  fmt.Errorf("Invalid markdown output 'markdown:///tmp/foobar.md?foo=bar': \nMust be of kind: markdown://PATH/TO/FILE.md?max_size=N, with N at least 1024"),
+		},
+		{
+			name: "test markdown with options only",
+			args: args{
+				out: []string{"markdown://?max_size=4096"},
+			},
+			want: []output.OutputConfig{},
+			err:  fmt.Errorf("Invalid markdown output 'markdown://?max_size=4096': \nMust be of kind: markdown://PATH/TO/FILE.md"),
+		},
 		{
 			name: "test multiple output values",
 			args: args{
@@ -297,7 +512,7 @@ func Test_parseOutputFlag(t *testing.T) {
 					Key: "console",
 				},
 			},
-			err: fmt.Errorf("Unsupported output 'invalid': \nValid formats are: console://,html://PATH/TO/FILE.html,json://PATH/TO/FILE.json,plan://PATH/TO/FILE.json"),
+			err: fmt.Errorf("Unsupported output 'invalid': \nValid formats are: console://,html://PATH/TO/FILE.html,json://PATH/TO/FILE.json,junit://PATH/TO/FILE.xml,markdown://PATH/TO/FILE.md,plan://PATH/TO/FILE.json,sarif://PATH/TO/FILE.sarif"),
 		},
 		{
 			name: "test multiple valid output values",
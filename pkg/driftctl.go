@@ -16,6 +16,7 @@ import (
 	"github.com/cloudskiff/driftctl/pkg/iac/config"
 	"github.com/cloudskiff/driftctl/pkg/iac/terraform/state/backend"
 	"github.com/cloudskiff/driftctl/pkg/middlewares"
+	"github.com/cloudskiff/driftctl/pkg/remote/common"
 	"github.com/cloudskiff/driftctl/pkg/resource"
 )
 
@@ -34,6 +35,20 @@ type ScanOptions struct {
 	ConfigDir        string
 	DriftignorePath  string
 	Deep             bool
+	// OnlyManagedTypes restricts the cloud provider scan to the types of
+	// resources found in IaC
+	OnlyManagedTypes bool
+	// OnlyFilteredTypes restricts the cloud provider scan to the types of
+	// resources matched by the filter expression
+	OnlyFilteredTypes bool
+	// ScanTypes restricts the cloud provider scan to resources of these types
+	// when not empty
+	ScanTypes []resource.ResourceType
+	// JSONSchemaVersion is the schema version used to serialize the analysis
+	JSONSchemaVersion int
+	Policy            analyser.Policy
+	History           bool
+	RemoteOptions     common.RemoteOptions
 }
 
 type DriftCTL struct {
@@ -188,6 +203,10 @@ func (d DriftCTL) scan() (remoteResources []*resource.Resource, resourcesFromSta
 		return nil, nil, err
 	}
 
+	if d.opts.OnlyManagedTypes {
+		d.restrictRemoteTypes(resourcesFromState)
+	}
+
 	logrus.Info("Start scanning cloud provider")
 	d.scanProgress.Start()
 	defer d.scanProgress.Stop()
@@ -198,3 +217,33 @@ func (d DriftCTL) scan() (remoteResources []*resource.Resource, resourcesFromSta
 
 	return remoteResources, resourcesFromState, err
 }
+
+// restrictRemoteTypes narrows the cloud provider scan down to the types of
+// resources found in IaC, along with the types middlewares need to reconcile them
+func (d DriftCTL) restrictRemoteTypes(resourcesFromState []*resource.Resource) {
+	supplier, ok := d.remoteSupplier.(resource.TypeRestrictableSupplier)
+	if !ok {
+		return
+	}
+
+	managedTypes := make([]resource.ResourceType, 0)
+	seen := make(map[string]struct{})
+	for _, res := range resourcesFromState {
+		if _, exist := seen[res.ResourceType()]; exist {
+			continue
+		}
+		seen[res.ResourceType()] = struct{}{}
+		managedTypes = append(managedTypes, resource.ResourceType(res.ResourceType()))
+	}
+
+	if len(managedTypes) == 0 {
+		logrus.Warn("No resource found in IaC, every resource type of the cloud provider is scanned")
+		return
+	}
+
+	types := resource.GetRelatedTypes(managedTypes)
+	logrus.WithFields(logrus.Fields{
+		"types": types,
+	}).Debug("Only scanning types of resources found in IaC")
+	supplier.RestrictTypes(types)
+}
@@ -3,8 +3,15 @@ package envproxy
 import (
 	"os"
 	"strings"
+	"sync"
 )
 
+// envLock serializes proxies, states are read concurrently and a proxy must
+// not restore the environment while another one creates its client
+var envLock sync.Mutex
+
+// EnvProxy exposes prefixed variables under another prefix, e.g. DCTL_S3_ ones
+// as AWS_ ones. Apply locks the environment until Restore is called.
 type EnvProxy struct {
 	fromPrefix string
 	toPrefix   string
@@ -12,15 +19,9 @@ type EnvProxy struct {
 }
 
 func NewEnvProxy(fromPrefix, toPrefix string) *EnvProxy {
-	envMap := map[string]string{}
-	for _, variable := range os.Environ() {
-		tmp := strings.SplitN(variable, "=", 2)
-		envMap[tmp[0]] = tmp[1]
-	}
 	return &EnvProxy{
 		fromPrefix: fromPrefix,
 		toPrefix:   toPrefix,
-		defaultEnv: envMap,
 	}
 }
 
@@ -28,6 +29,14 @@ func (s *EnvProxy) Apply() {
 	if s.fromPrefix == "" || s.toPrefix == "" {
 		return
 	}
+	envLock.Lock()
+	// The environment is read once locked, otherwise variables applied by
+	// another proxy could be restored
+	s.defaultEnv = map[string]string{}
+	for _, variable := range os.Environ() {
+		tmp := strings.SplitN(variable, "=", 2)
+		s.defaultEnv[tmp[0]] = tmp[1]
+	}
 	for key, value := range s.defaultEnv {
 		if strings.HasPrefix(key, s.fromPrefix) {
 			key = strings.Replace(key, s.fromPrefix, s.toPrefix, 1)
@@ -47,4 +56,5 @@ func (s *EnvProxy) Restore() {
 		}
 		os.Setenv(key, value)
 	}
+	envLock.Unlock()
 }
@@ -2,7 +2,9 @@ package envproxy
 
 import (
 	"os"
+	"runtime"
 	"strings"
+	"sync"
 	"testing"
 )
 
@@ -83,3 +85,30 @@ func compareEnv(currentEnv, testEnv []string) bool {
 	}
 	return isValid == len(testEnv)
 }
+
+func TestEnvProxy_Concurrent(t *testing.T) {
+	os.Setenv("TEST_DCTL_S3_PROFILE", "test_dctl_s3_profile")
+	os.Setenv("TEST_AWS_PROFILE", "test_aws_profile")
+	defer os.Unsetenv("TEST_DCTL_S3_PROFILE")
+	defer os.Unsetenv("TEST_AWS_PROFILE")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			envProxy := NewEnvProxy("TEST_DCTL_S3_", "TEST_AWS_")
+			envProxy.Apply()
+			defer envProxy.Restore()
+			runtime.Gosched()
+			if got := os.Getenv("TEST_AWS_PROFILE"); got != "test_dctl_s3_profile" {
+				t.Errorf("Expected applied profile, got %s", got)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := os.Getenv("TEST_AWS_PROFILE"); got != "test_aws_profile" {
+		t.Errorf("Expected restored profile, got %s", got)
+	}
+}
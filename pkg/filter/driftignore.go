@@ -3,8 +3,12 @@ package filter
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"regexp"
 	"strings"
+	"sync"
 
 	"github.com/cloudskiff/driftctl/pkg/resource"
 	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
@@ -13,15 +17,66 @@ import (
 
 const separator = "_-_"
 
+const driftignoreFilename = ".driftignore"
+
+// predicatesRegex matches trailing attribute predicates of a line, e.g.
+// aws_iam_role[path=/service-role/*][tags.owner=platform]
+// An equal sign is required so gitignore character classes like type[12] are
+// not mistaken for predicates
+var predicatesRegex = regexp.MustCompile(`^(.+?)((?:\[[^\[\]=]+=[^\[\]]*\])+)$`)
+var predicateRegex = regexp.MustCompile(`\[([^\[\]=]+)=([^\[\]]*)\]`)
+
+// sectionRegex matches section headers scoping the following rules to
+// resources coming from a given IaC source, e.g.
+// [source=s3://bucket/team-a/**][module=module.network*]
+// The [*] header goes back to rules applying to every resource
+var sectionRegex = regexp.MustCompile(`^\s*(\[\*\]|(?:\[(?:source|module)=[^\[\]]+\])+)\s*$`)
+var sectionConditionRegex = regexp.MustCompile(`\[(source|module)=([^\[\]]+)\]`)
+
+// attributePredicate matches resources having an attribute, looked up by its
+// dotted path, whose value matches a glob pattern
+type attributePredicate struct {
+	path  []string
+	value *regexp.Regexp
+}
+
+// driftIgnoreScope restricts rules to resources read from matching IaC sources
+type driftIgnoreScope struct {
+	source *regexp.Regexp
+	module *regexp.Regexp
+}
+
+// driftIgnoreRule is a single line of the driftignore file. Rules with
+// predicates or a scope only apply to resources and never to whole types
+type driftIgnoreRule struct {
+	patterns   []gitignore.Pattern
+	predicates []attributePredicate
+	scope      *driftIgnoreScope
+	negated    bool
+}
+
+// DriftIgnore reads ignore rules from the given file. When it is the default
+// .driftignore file of the working directory, .driftignore files found next
+// to local state files are read too. The latter only apply to resources read
+// from the state file they sit next to, cannot ignore whole types and take
+// precedence over rules of the default file.
 type DriftIgnore struct {
 	driftignorePath string
-	matcher         gitignore.Matcher
+	rules           []driftIgnoreRule
+	// readStateDirs enables driftignore files next to local states, they are
+	// not read when another driftignore file is given
+	readStateDirs bool
+	// stateRules caches rules of driftignore files found next to local states,
+	// indexed by source
+	stateRules map[string][]driftIgnoreRule
+	mu         sync.Mutex
 }
 
 func NewDriftIgnore(path string) *DriftIgnore {
 	d := DriftIgnore{
 		driftignorePath: path,
-		matcher:         gitignore.NewMatcher(nil),
+		readStateDirs:   filepath.Clean(path) == driftignoreFilename,
+		stateRules:      map[string][]driftIgnoreRule{},
 	}
 	err := d.readIgnoreFile()
 	if err != nil {
@@ -37,8 +92,18 @@ func (r *DriftIgnore) readIgnoreFile() error {
 	}
 	defer file.Close()
 
-	var lines []gitignore.Pattern
-	scanner := bufio.NewScanner(file)
+	rules, err := parseRules(file, nil)
+	if err != nil {
+		return err
+	}
+	r.rules = rules
+
+	return nil
+}
+
+func parseRules(reader io.Reader, scope *driftIgnoreScope) ([]driftIgnoreRule, error) {
+	var rules []driftIgnoreRule
+	scanner := bufio.NewScanner(reader)
 	for lineNumber := 1; scanner.Scan(); lineNumber++ {
 		line := scanner.Text()
 
@@ -49,22 +114,169 @@ func (r *DriftIgnore) readIgnoreFile() error {
 		if strings.HasPrefix(line, "#") {
 			continue // this is a comment
 		}
+
+		if matches := sectionRegex.FindStringSubmatch(line); matches != nil {
+			scope = newDriftIgnoreScope(matches[1])
+			continue
+		}
+
+		rule := driftIgnoreRule{negated: strings.HasPrefix(line, "!"), scope: scope}
+		if matches := predicatesRegex.FindStringSubmatch(line); matches != nil {
+			line = matches[1]
+			for _, predicate := range predicateRegex.FindAllStringSubmatch(matches[2], -1) {
+				rule.predicates = append(rule.predicates, newAttributePredicate(predicate[1], predicate[2]))
+			}
+		}
 		line = strings.ReplaceAll(line, "/", separator)
 
-		lines = append(lines, gitignore.ParsePattern(line, nil))
+		rule.patterns = append(rule.patterns, gitignore.ParsePattern(line, nil))
 		if !strings.HasSuffix(line, "*") {
 			line := fmt.Sprintf("%s.*", line)
-			lines = append(lines, gitignore.ParsePattern(line, nil))
+			rule.patterns = append(rule.patterns, gitignore.ParsePattern(line, nil))
 		}
+		rules = append(rules, rule)
 	}
 
 	if err := scanner.Err(); err != nil {
-		return err
+		return nil, err
 	}
 
-	r.matcher = gitignore.NewMatcher(lines)
+	return rules, nil
+}
 
-	return nil
+func newAttributePredicate(path, value string) attributePredicate {
+	// Only * is a wildcard in values, and it also matches slashes so paths
+	// like /aws-service-role/* can be used
+	pattern := strings.ReplaceAll(regexp.QuoteMeta(strings.TrimSpace(value)), `\*`, ".*")
+	return attributePredicate{
+		path:  strings.Split(strings.TrimSpace(path), "."),
+		value: regexp.MustCompile(fmt.Sprintf("^%s$", pattern)),
+	}
+}
+
+func (p attributePredicate) match(res *resource.Resource) bool {
+	if res.Attributes() == nil {
+		return false
+	}
+
+	var value interface{} = map[string]interface{}(*res.Attributes())
+	for _, key := range p.path {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			value = v[key]
+		case resource.Attributes:
+			value = v[key]
+		default:
+			return false
+		}
+	}
+
+	switch value.(type) {
+	case nil, map[string]interface{}, resource.Attributes, []interface{}:
+		return false
+	}
+	return p.value.MatchString(fmt.Sprint(value))
+}
+
+// newDriftIgnoreScope builds a scope from a section header, a nil scope
+// meaning rules apply to every resource
+func newDriftIgnoreScope(header string) *driftIgnoreScope {
+	if header == "[*]" {
+		return nil
+	}
+	scope := &driftIgnoreScope{}
+	for _, condition := range sectionConditionRegex.FindAllStringSubmatch(header, -1) {
+		switch condition[1] {
+		case "source":
+			scope.source = globToRegexp(strings.TrimSpace(condition[2]))
+		case "module":
+			scope.module = globToRegexp(strings.TrimSpace(condition[2]))
+		}
+	}
+	return scope
+}
+
+// globToRegexp converts a path glob where ** matches any sequence of
+// characters and * or ? do not match slashes
+func globToRegexp(glob string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(glob); i++ {
+		switch {
+		case strings.HasPrefix(glob[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case glob[i] == '*':
+			b.WriteString("[^/]*")
+		case glob[i] == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		}
+	}
+	b.WriteString("$")
+	return regexp.MustCompile(b.String())
+}
+
+// match checks the scope against the resource source. The source can be
+// given either fully (e.g. tfstate+s3://bucket/key) or without the tfstate
+// supplier prefix (e.g. s3://bucket/key or a local path)
+func (s *driftIgnoreScope) match(res *resource.Resource) bool {
+	if res.Source == nil {
+		return false
+	}
+	if s.source != nil {
+		source := res.Source.Source()
+		short := strings.TrimPrefix(strings.TrimPrefix(source, "tfstate://"), "tfstate+")
+		if !s.source.MatchString(source) && !s.source.MatchString(short) {
+			return false
+		}
+	}
+	if s.module != nil && !s.module.MatchString(res.Source.Namespace()) {
+		return false
+	}
+	return true
+}
+
+// rulesFromStateDir returns rules of the driftignore file sitting next to the
+// local state the resource comes from, if any
+func (r *DriftIgnore) rulesFromStateDir(res *resource.Resource) []driftIgnoreRule {
+	if !r.readStateDirs || res.Source == nil || !strings.HasPrefix(res.Source.Source(), "tfstate://") {
+		return nil
+	}
+	source := res.Source.Source()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if rules, exist := r.stateRules[source]; exist {
+		return rules
+	}
+
+	var rules []driftIgnoreRule
+	path := filepath.Join(filepath.Dir(strings.TrimPrefix(source, "tfstate://")), driftignoreFilename)
+	if !isSameFile(path, r.driftignorePath) {
+		if file, err := os.Open(path); err == nil {
+			scope := &driftIgnoreScope{source: regexp.MustCompile(fmt.Sprintf("^%s$", regexp.QuoteMeta(source)))}
+			rules, err = parseRules(file, scope)
+			if err != nil {
+				logrus.WithFields(logrus.Fields{
+					"path": path,
+					"err":  err.Error(),
+				}).Debug("Unable to read driftignore file next to state")
+			}
+			file.Close()
+		}
+	}
+	r.stateRules[source] = rules
+
+	return rules
+}
+
+func isSameFile(a, b string) bool {
+	absA, errA := filepath.Abs(a)
+	absB, errB := filepath.Abs(b)
+	return errA == nil && errB == nil && absA == absB
 }
 
 func (r *DriftIgnore) isAnyOfChildrenTypesNotIgnored(ty resource.ResourceType) bool {
@@ -80,6 +292,18 @@ func (r *DriftIgnore) isAnyOfChildrenTypesNotIgnored(ty resource.ResourceType) b
 	return false
 }
 
+// isAnyResourceOfTypeNotIgnored returns true when a negated rule with
+// predicates or a scope may keep some resources of the given type
+func (r *DriftIgnore) isAnyResourceOfTypeNotIgnored(ty resource.ResourceType) bool {
+	path := []string{strings.ReplaceAll(fmt.Sprintf("%s.*", ty), "/", separator)}
+	for _, rule := range r.rules {
+		if rule.negated && !rule.isGlobal() && rule.matchPath(path) != gitignore.NoMatch {
+			return true
+		}
+	}
+	return false
+}
+
 func (r *DriftIgnore) IsTypeIgnored(ty resource.ResourceType) bool {
 	// Iterate over children types, and do not ignore parent resource
 	// if at least one of children type is not ignored.
@@ -87,18 +311,77 @@ func (r *DriftIgnore) IsTypeIgnored(ty resource.ResourceType) bool {
 		return false
 	}
 
+	if r.isAnyResourceOfTypeNotIgnored(ty) {
+		return false
+	}
+
 	return r.match(fmt.Sprintf("%s.*", ty))
 }
 
 func (r *DriftIgnore) IsResourceIgnored(res *resource.Resource) bool {
-	return r.match(fmt.Sprintf("%s.%s", res.ResourceType(), res.ResourceId()))
+	path := fmt.Sprintf("%s.%s", res.ResourceType(), res.ResourceId())
+	accept := func(rule driftIgnoreRule) bool {
+		return rule.matchScope(res) && rule.matchPredicates(res)
+	}
+	// Rules next to the state take precedence over global ones
+	if result := matchRules(r.rulesFromStateDir(res), path, accept); result != gitignore.NoMatch {
+		return result == gitignore.Exclude
+	}
+	return matchRules(r.rules, path, accept) == gitignore.Exclude
 }
 
 func (r *DriftIgnore) IsFieldIgnored(res *resource.Resource, path []string) bool {
 	full := fmt.Sprintf("%s.%s.%s", res.ResourceType(), res.ResourceId(), strings.Join(path, "."))
-	return r.match(full)
+	accept := func(rule driftIgnoreRule) bool {
+		return len(rule.predicates) == 0 && rule.matchScope(res)
+	}
+	if result := matchRules(r.rulesFromStateDir(res), full, accept); result != gitignore.NoMatch {
+		return result == gitignore.Exclude
+	}
+	return matchRules(r.rules, full, accept) == gitignore.Exclude
 }
 
 func (r *DriftIgnore) match(strRes string) bool {
-	return r.matcher.Match([]string{strings.ReplaceAll(strRes, "/", separator)}, false)
+	return matchRules(r.rules, strRes, driftIgnoreRule.isGlobal) == gitignore.Exclude
+}
+
+// matchRules evaluates accepted rules against the path, like gitignore the
+// last matching rule wins
+func matchRules(rules []driftIgnoreRule, strRes string, accept func(driftIgnoreRule) bool) gitignore.MatchResult {
+	path := []string{strings.ReplaceAll(strRes, "/", separator)}
+	for i := len(rules) - 1; i >= 0; i-- {
+		if !accept(rules[i]) {
+			continue
+		}
+		if result := rules[i].matchPath(path); result != gitignore.NoMatch {
+			return result
+		}
+	}
+	return gitignore.NoMatch
+}
+
+func (r driftIgnoreRule) isGlobal() bool {
+	return len(r.predicates) == 0 && r.scope == nil
+}
+
+func (r driftIgnoreRule) matchPath(path []string) gitignore.MatchResult {
+	for _, pattern := range r.patterns {
+		if result := pattern.Match(path, false); result != gitignore.NoMatch {
+			return result
+		}
+	}
+	return gitignore.NoMatch
+}
+
+func (r driftIgnoreRule) matchScope(res *resource.Resource) bool {
+	return r.scope == nil || r.scope.match(res)
+}
+
+func (r driftIgnoreRule) matchPredicates(res *resource.Resource) bool {
+	for _, predicate := range r.predicates {
+		if !predicate.match(res) {
+			return false
+		}
+	}
+	return true
 }
@@ -16,6 +16,7 @@ func TestDriftIgnore_IsResourceIgnored(t *testing.T) {
 		resources []*resource.Resource
 		want      []bool
 		path      string
+		dir       string
 	}{
 		{
 			name: "drift_ignore_no_file",
@@ -215,11 +216,223 @@ func TestDriftIgnore_IsResourceIgnored(t *testing.T) {
 			},
 			path: "testdata/drift_ignore_all_exclude/.driftignore",
 		},
+		{
+			name: "drift_ignore_attributes",
+			resources: []*resource.Resource{
+				{
+					Type: "aws_iam_role",
+					Id:   "AWSServiceRoleForSupport",
+					Attrs: &resource.Attributes{
+						"path": "/aws-service-role/support.amazonaws.com/",
+					},
+				},
+				{
+					Type: "aws_iam_role",
+					Id:   "my-role",
+					Attrs: &resource.Attributes{
+						"path": "/",
+					},
+				},
+				{
+					Type: "aws_iam_role",
+					Id:   "no-attributes",
+				},
+				{
+					Type: "aws_iam_user",
+					Id:   "platform-user",
+					Attrs: &resource.Attributes{
+						"tags": map[string]interface{}{
+							"owner": "platform",
+						},
+					},
+				},
+				{
+					Type: "aws_iam_user",
+					Id:   "other-user",
+					Attrs: &resource.Attributes{
+						"tags": map[string]interface{}{
+							"owner": "security",
+						},
+					},
+				},
+				{
+					Type: "aws_s3_bucket",
+					Id:   "platform-bucket",
+					Attrs: &resource.Attributes{
+						"tags": map[string]interface{}{
+							"owner": "platform",
+						},
+					},
+				},
+				{
+					Type: "aws_instance",
+					Id:   "dev-instance",
+					Attrs: &resource.Attributes{
+						"monitoring": false,
+						"tags": map[string]interface{}{
+							"env": "dev",
+						},
+					},
+				},
+				{
+					Type: "aws_instance",
+					Id:   "monitored-dev-instance",
+					Attrs: &resource.Attributes{
+						"monitoring": true,
+						"tags": map[string]interface{}{
+							"env": "dev",
+						},
+					},
+				},
+				{
+					Type: "type1",
+					Id:   "id1",
+				},
+				{
+					Type: "type3",
+					Id:   "id1",
+				},
+			},
+			want: []bool{
+				true,
+				false,
+				false,
+				true,
+				false,
+				false,
+				true,
+				false,
+				true,
+				false,
+			},
+			path: "testdata/drift_ignore_attributes/.driftignore",
+		},
+		{
+			name: "drift_ignore_scoped",
+			resources: []*resource.Resource{
+				{
+					Type:   "global_resource",
+					Id:     "id1",
+					Source: resource.NewTerraformStateSource("tfstate+s3://bucket/team-b/terraform.tfstate", "", "foo"),
+				},
+				{
+					Type:   "aws_s3_bucket",
+					Id:     "team-a-bucket",
+					Source: resource.NewTerraformStateSource("tfstate+s3://bucket/team-a/prod/terraform.tfstate", "", "foo"),
+				},
+				{
+					Type:   "aws_s3_bucket",
+					Id:     "team-b-bucket",
+					Source: resource.NewTerraformStateSource("tfstate+s3://bucket/team-b/terraform.tfstate", "", "foo"),
+				},
+				{
+					Type: "aws_s3_bucket",
+					Id:   "unmanaged-bucket",
+				},
+				{
+					Type:   "aws_vpc",
+					Id:     "other",
+					Source: resource.NewTerraformStateSource("tfstate://local/terraform.tfstate", "module.network", "foo"),
+				},
+				{
+					Type:   "aws_vpc",
+					Id:     "main",
+					Source: resource.NewTerraformStateSource("tfstate://local/terraform.tfstate", "module.network", "main"),
+				},
+				{
+					Type:   "aws_vpc",
+					Id:     "root-module",
+					Source: resource.NewTerraformStateSource("tfstate://local/terraform.tfstate", "", "foo"),
+				},
+				{
+					Type:   "aws_vpc",
+					Id:     "nested-dir",
+					Source: resource.NewTerraformStateSource("tfstate://local/nested/terraform.tfstate", "module.network", "foo"),
+				},
+				{
+					Type: "aws_instance",
+					Id:   "id1",
+				},
+			},
+			want: []bool{
+				true,
+				true,
+				false,
+				false,
+				true,
+				false,
+				false,
+				false,
+				true,
+			},
+			path: "testdata/drift_ignore_scoped/.driftignore",
+		},
+		{
+			name: "drift_ignore_next_to_state",
+			resources: []*resource.Resource{
+				{
+					Type:   "aws_s3_bucket",
+					Id:     "team-a-bucket",
+					Source: resource.NewTerraformStateSource("tfstate://team-a/terraform.tfstate", "", "foo"),
+				},
+				{
+					Type:   "aws_s3_bucket",
+					Id:     "team-b-bucket",
+					Source: resource.NewTerraformStateSource("tfstate://team-b/terraform.tfstate", "", "foo"),
+				},
+				{
+					Type: "aws_s3_bucket",
+					Id:   "unmanaged-bucket",
+				},
+				{
+					Type:   "global_resource",
+					Id:     "kept",
+					Source: resource.NewTerraformStateSource("tfstate://team-a/terraform.tfstate", "", "foo"),
+				},
+				{
+					Type:   "global_resource",
+					Id:     "kept",
+					Source: resource.NewTerraformStateSource("tfstate://terraform.tfstate", "", "foo"),
+				},
+			},
+			want: []bool{
+				true,
+				false,
+				false,
+				false,
+				true,
+			},
+			path: ".driftignore",
+			dir:  "testdata/drift_ignore_state_dir",
+		},
+		{
+			name: "drift_ignore_explicit_path_not_overridden_next_to_state",
+			resources: []*resource.Resource{
+				{
+					Type:   "aws_s3_bucket",
+					Id:     "team-a-bucket",
+					Source: resource.NewTerraformStateSource("tfstate://testdata/drift_ignore_state_dir/team-a/terraform.tfstate", "", "foo"),
+				},
+				{
+					Type:   "global_resource",
+					Id:     "kept",
+					Source: resource.NewTerraformStateSource("tfstate://testdata/drift_ignore_state_dir/team-a/terraform.tfstate", "", "foo"),
+				},
+			},
+			want: []bool{
+				false,
+				true,
+			},
+			path: "testdata/drift_ignore_state_dir/.driftignore",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			cwd, _ := os.Getwd()
 			defer func() { _ = os.Chdir(cwd) }()
+			if tt.dir != "" {
+				assert.NoError(t, os.Chdir(tt.dir))
+			}
 
 			r := NewDriftIgnore(tt.path)
 			got := make([]bool, 0, len(tt.want))
@@ -519,6 +732,30 @@ func TestDriftIgnore_IsTypeIgnored(t *testing.T) {
 			},
 			path: "testdata/drift_ignore_type/.driftignore",
 		},
+		{
+			name: "drift_ignore_type_exclude_with_attributes",
+			resources: []*resource.Resource{
+				{
+					Type: "aws_s3_bucket",
+				},
+				{
+					Type: "aws_iam_role",
+				},
+				{
+					Type: "type",
+				},
+				{
+					Type: "aws_instance",
+				},
+			},
+			want: []bool{
+				false,
+				true,
+				true,
+				false,
+			},
+			path: "testdata/drift_ignore_type/.driftignore_attributes",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
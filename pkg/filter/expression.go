@@ -1,9 +1,15 @@
 package filter
 
 import (
+	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
+	"unicode"
 
+	"github.com/cloudskiff/driftctl/pkg/resource"
 	"github.com/jmespath/go-jmespath"
+	"github.com/pkg/errors"
 )
 
 func BuildExpression(expressionStr string) (*jmespath.JMESPath, error) {
@@ -13,3 +19,265 @@ func BuildExpression(expressionStr string) (*jmespath.JMESPath, error) {
 	}
 	return expr, nil
 }
+
+// ExpressionTypes statically derives the resource types a filter expression
+// can match. Types can only be derived when the expression compares the Type
+// field to string literals, combined with && and || operators. The second
+// returned value is false when the expression may match any type.
+func ExpressionTypes(expressionStr string) ([]resource.ResourceType, bool) {
+	tokens, err := tokenizeExpression(expressionStr)
+	if err != nil {
+		return nil, false
+	}
+	parser := &typesParser{tokens: tokens}
+	types, err := parser.parseOr()
+	if err != nil || parser.pos != len(tokens) || types == nil {
+		return nil, false
+	}
+
+	results := make([]resource.ResourceType, 0, len(types))
+	for ty := range types {
+		results = append(results, ty)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i] < results[j]
+	})
+	return results, true
+}
+
+type tokenKind int
+
+const (
+	tokenOperator tokenKind = iota
+	tokenIdentifier
+	tokenQuotedIdentifier
+	tokenRawString
+	tokenJSONLiteral
+)
+
+type expressionToken struct {
+	kind  tokenKind
+	value string
+}
+
+var twoCharsOperators = []string{"&&", "||", "==", "!=", "<=", ">="}
+
+// tokenizeExpression splits a JMESPath expression into tokens, it only
+// understands what is needed to find comparisons of the Type field
+func tokenizeExpression(expressionStr string) ([]expressionToken, error) {
+	runes := []rune(expressionStr)
+	tokens := make([]expressionToken, 0)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '\'' || r == '"' || r == '`':
+			value, next, err := readDelimited(runes, i)
+			if err != nil {
+				return nil, err
+			}
+			kind := map[rune]tokenKind{'\'': tokenRawString, '"': tokenQuotedIdentifier, '`': tokenJSONLiteral}[r]
+			tokens = append(tokens, expressionToken{kind, value})
+			i = next
+		case r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r):
+			start := i
+			for i < len(runes) && (runes[i] == '_' || unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i])) {
+				i++
+			}
+			tokens = append(tokens, expressionToken{tokenIdentifier, string(runes[start:i])})
+		default:
+			value := string(r)
+			for _, op := range twoCharsOperators {
+				if strings.HasPrefix(string(runes[i:]), op) {
+					value = op
+					break
+				}
+			}
+			tokens = append(tokens, expressionToken{tokenOperator, value})
+			i += len([]rune(value))
+		}
+	}
+	return tokens, nil
+}
+
+// readDelimited reads a literal starting at given position until its closing
+// delimiter, it returns the literal content and the position following it
+func readDelimited(runes []rune, start int) (string, int, error) {
+	delimiter := runes[start]
+	var value strings.Builder
+	for i := start + 1; i < len(runes); i++ {
+		switch runes[i] {
+		case '\\':
+			if i+1 < len(runes) && (runes[i+1] == delimiter || runes[i+1] == '\\') {
+				i++
+			}
+		case delimiter:
+			return value.String(), i + 1, nil
+		}
+		value.WriteRune(runes[i])
+	}
+	return "", 0, errors.Errorf("unclosed %c literal", delimiter)
+}
+
+// typeSet holds the types an expression can match, nil means any type
+type typeSet map[resource.ResourceType]struct{}
+
+func union(a, b typeSet) typeSet {
+	if a == nil || b == nil {
+		return nil
+	}
+	result := typeSet{}
+	for ty := range a {
+		result[ty] = struct{}{}
+	}
+	for ty := range b {
+		result[ty] = struct{}{}
+	}
+	return result
+}
+
+func intersection(a, b typeSet) typeSet {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	result := typeSet{}
+	for ty := range a {
+		if _, exist := b[ty]; exist {
+			result[ty] = struct{}{}
+		}
+	}
+	return result
+}
+
+type typesParser struct {
+	tokens []expressionToken
+	pos    int
+}
+
+func (p *typesParser) peek() (expressionToken, bool) {
+	if p.pos >= len(p.tokens) {
+		return expressionToken{}, false
+	}
+	return p.tokens[p.pos], true
+}
+
+func (p *typesParser) isOperator(value string) bool {
+	token, ok := p.peek()
+	return ok && token.kind == tokenOperator && token.value == value
+}
+
+func (p *typesParser) parseOr() (typeSet, error) {
+	types, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOperator("||") {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		types = union(types, right)
+	}
+	return types, nil
+}
+
+func (p *typesParser) parseAnd() (typeSet, error) {
+	types, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOperator("&&") {
+		p.pos++
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		types = intersection(types, right)
+	}
+	return types, nil
+}
+
+func (p *typesParser) parseUnary() (typeSet, error) {
+	if p.isOperator("!") {
+		// A negation may match any type
+		p.pos++
+		_, err := p.parseUnary()
+		return nil, err
+	}
+	if p.isOperator("(") {
+		p.pos++
+		types, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.isOperator(")") {
+			return nil, errors.New("expected closing parenthesis")
+		}
+		p.pos++
+		if _, ok := p.peek(); ok && !p.isOperator("&&") && !p.isOperator("||") && !p.isOperator(")") {
+			return nil, errors.New("unexpected token after parenthesis")
+		}
+		return types, nil
+	}
+	return p.parseComparison()
+}
+
+// parseComparison reads tokens until the end of the current operand, the
+// operand only restricts types when it compares the Type field to a string
+func (p *typesParser) parseComparison() (typeSet, error) {
+	start := p.pos
+	depth := 0
+	for token, ok := p.peek(); ok; token, ok = p.peek() {
+		if token.kind == tokenOperator {
+			if depth == 0 && (token.value == "&&" || token.value == "||" || token.value == ")") {
+				break
+			}
+			switch token.value {
+			case "(", "[", "{":
+				depth++
+			case ")", "]", "}":
+				depth--
+			}
+		}
+		p.pos++
+	}
+	operand := p.tokens[start:p.pos]
+	if len(operand) == 0 {
+		return nil, errors.New("expected operand")
+	}
+	if len(operand) != 3 || operand[1].kind != tokenOperator || operand[1].value != "==" {
+		return nil, nil
+	}
+	for _, pair := range [][2]expressionToken{{operand[0], operand[2]}, {operand[2], operand[0]}} {
+		if !isTypeField(pair[0]) {
+			continue
+		}
+		if ty, ok := stringLiteral(pair[1]); ok {
+			return typeSet{resource.ResourceType(ty): {}}, nil
+		}
+	}
+	return nil, nil
+}
+
+func isTypeField(token expressionToken) bool {
+	return (token.kind == tokenIdentifier || token.kind == tokenQuotedIdentifier) && token.value == "Type"
+}
+
+func stringLiteral(token expressionToken) (string, bool) {
+	switch token.kind {
+	case tokenRawString:
+		return token.value, true
+	case tokenJSONLiteral:
+		var value string
+		if err := json.Unmarshal([]byte(token.value), &value); err != nil {
+			return "", false
+		}
+		return value, true
+	}
+	return "", false
+}
@@ -0,0 +1,91 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/cloudskiff/driftctl/pkg/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpressionTypes(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		want       []resource.ResourceType
+		restricted bool
+	}{
+		{
+			name:       "single type",
+			expression: "Type=='aws_s3_bucket'",
+			want:       []resource.ResourceType{"aws_s3_bucket"},
+			restricted: true,
+		},
+		{
+			name:       "reversed comparison with json literal",
+			expression: "`\"aws_s3_bucket\"` == \"Type\"",
+			want:       []resource.ResourceType{"aws_s3_bucket"},
+			restricted: true,
+		},
+		{
+			name:       "union of types",
+			expression: "Type=='aws_s3_bucket' || Type=='aws_iam_user'",
+			want:       []resource.ResourceType{"aws_iam_user", "aws_s3_bucket"},
+			restricted: true,
+		},
+		{
+			name:       "type and attribute",
+			expression: "Type=='aws_s3_bucket' && Attr.bucket=='my-bucket'",
+			want:       []resource.ResourceType{"aws_s3_bucket"},
+			restricted: true,
+		},
+		{
+			name:       "grouped expressions",
+			expression: "(Type=='aws_s3_bucket' || Type=='aws_iam_user') && (Type=='aws_iam_user' || Type=='aws_sqs_queue')",
+			want:       []resource.ResourceType{"aws_iam_user"},
+			restricted: true,
+		},
+		{
+			name:       "grouped expression and function",
+			expression: "(Type=='aws_s3_bucket' || Type=='aws_iam_user') && contains(Id, 'foo')",
+			want:       []resource.ResourceType{"aws_iam_user", "aws_s3_bucket"},
+			restricted: true,
+		},
+		{
+			name:       "exclusive types",
+			expression: "Type=='aws_s3_bucket' && Type=='aws_iam_user'",
+			want:       []resource.ResourceType{},
+			restricted: true,
+		},
+		{
+			name:       "attribute only",
+			expression: "Attr.Type=='aws_s3_bucket'",
+		},
+		{
+			name:       "union with attribute",
+			expression: "Type=='aws_s3_bucket' || Id=='my-bucket'",
+		},
+		{
+			name:       "negation",
+			expression: "!(Type=='aws_s3_bucket')",
+		},
+		{
+			name:       "type inequality",
+			expression: "Type!='aws_s3_bucket'",
+		},
+		{
+			name:       "type in function",
+			expression: "contains(['aws_s3_bucket'], Type)",
+		},
+		{
+			name:       "invalid expression",
+			expression: "Type=='aws_s3_bucket",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, restricted := ExpressionTypes(tt.expression)
+			assert.Equal(t, tt.restricted, restricted)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
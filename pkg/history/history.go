@@ -0,0 +1,128 @@
+package history
+
+import (
+	"encoding/json"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cloudskiff/driftctl/pkg/analyser"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const entryIdFormat = "20060102T150405.000000000Z"
+
+// Entry is a single scan recorded in the history
+type Entry struct {
+	Id              string            `json:"-"`
+	Date            time.Time         `json:"date"`
+	Duration        time.Duration     `json:"duration"`
+	ProviderName    string            `json:"provider_name"`
+	ProviderVersion string            `json:"provider_version"`
+	Sources         []string          `json:"sources"`
+	Analysis        analyser.Analysis `json:"analysis"`
+}
+
+func NewEntry(analysis *analyser.Analysis, sources []string) Entry {
+	return Entry{
+		Date:            analysis.Date,
+		Duration:        analysis.Duration,
+		ProviderName:    analysis.ProviderName,
+		ProviderVersion: analysis.ProviderVersion,
+		Sources:         sources,
+		Analysis:        *analysis,
+	}
+}
+
+// Store keeps every entry as a JSON file in a local directory, no server is
+// involved
+type Store struct {
+	dir string
+}
+
+func NewStore(configDir string) *Store {
+	return &Store{path.Join(configDir, ".driftctl", "history")}
+}
+
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+func (s *Store) Append(entry Entry) (string, error) {
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return "", err
+	}
+
+	content, err := json.Marshal(entry)
+	if err != nil {
+		return "", err
+	}
+
+	id := entry.Date.UTC().Format(entryIdFormat)
+	if err := os.WriteFile(s.entryPath(id), content, 0600); err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+// List returns entries sorted from the oldest to the newest one
+func (s *Store) List() ([]Entry, error) {
+	files, err := filepath.Glob(path.Join(s.dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+
+	entries := make([]Entry, 0, len(files))
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		entry := Entry{}
+		if err := json.Unmarshal(content, &entry); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"file":  file,
+				"error": err,
+			}).Warn("Ignoring invalid history entry")
+			continue
+		}
+		entry.Id = strings.TrimSuffix(path.Base(file), ".json")
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// Prune removes entries older than the given date and keeps at most keep
+// entries, a negative keep disables the count limit.
+// It returns the number of removed entries.
+func (s *Store) Prune(olderThan time.Time, keep int) (int, error) {
+	entries, err := s.List()
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for i, entry := range entries {
+		tooMany := keep >= 0 && len(entries)-i > keep
+		if !entry.Date.Before(olderThan) && !tooMany {
+			continue
+		}
+		if err := os.Remove(s.entryPath(entry.Id)); err != nil {
+			return removed, errors.Wrapf(err, "unable to remove history entry %s", entry.Id)
+		}
+		removed++
+	}
+
+	return removed, nil
+}
+
+func (s *Store) entryPath(id string) string {
+	return path.Join(s.dir, id+".json")
+}
@@ -0,0 +1,78 @@
+package history
+
+import (
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/cloudskiff/driftctl/pkg/analyser"
+	"github.com/cloudskiff/driftctl/pkg/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fakeAnalysis(date time.Time, managed, unmanaged int) *analyser.Analysis {
+	a := &analyser.Analysis{
+		Date:            date,
+		Duration:        time.Minute,
+		ProviderName:    "AWS",
+		ProviderVersion: "3.19.0",
+	}
+	for i := 0; i < managed; i++ {
+		a.AddManaged(&resource.Resource{Id: "managed", Type: "aws_s3_bucket"})
+	}
+	for i := 0; i < unmanaged; i++ {
+		a.AddUnmanaged(&resource.Resource{Id: "unmanaged", Type: "aws_s3_bucket"})
+	}
+	return a
+}
+
+func TestStore(t *testing.T) {
+	configDir := t.TempDir()
+	store := NewStore(configDir)
+	assert.Equal(t, path.Join(configDir, ".driftctl", "history"), store.Dir())
+
+	entries, err := store.List()
+	require.Nil(t, err)
+	assert.Empty(t, entries)
+
+	first := time.Date(2021, 10, 1, 12, 0, 0, 0, time.UTC)
+	second := time.Date(2021, 10, 2, 12, 0, 0, 0, time.UTC)
+	third := time.Date(2021, 10, 3, 12, 0, 0, 0, time.UTC)
+
+	// Insert them in a random order to make sure they are sorted by date
+	for _, date := range []time.Time{second, third, first} {
+		_, err := store.Append(NewEntry(fakeAnalysis(date, 1, 1), []string{"tfstate://terraform.tfstate"}))
+		require.Nil(t, err)
+	}
+	// Invalid entries are ignored
+	require.Nil(t, os.WriteFile(path.Join(store.Dir(), "invalid.json"), []byte("{"), 0600))
+
+	entries, err = store.List()
+	require.Nil(t, err)
+	require.Len(t, entries, 3)
+	assert.Equal(t, "20211001T120000.000000000Z", entries[0].Id)
+	assert.True(t, first.Equal(entries[0].Date))
+	assert.True(t, second.Equal(entries[1].Date))
+	assert.True(t, third.Equal(entries[2].Date))
+	assert.Equal(t, "AWS", entries[0].ProviderName)
+	assert.Equal(t, "3.19.0", entries[0].ProviderVersion)
+	assert.Equal(t, time.Minute, entries[0].Duration)
+	assert.Equal(t, []string{"tfstate://terraform.tfstate"}, entries[0].Sources)
+	assert.Equal(t, 50, entries[0].Analysis.Coverage())
+	assert.Equal(t, 2, entries[0].Analysis.Summary().TotalResources)
+
+	removed, err := store.Prune(second, -1)
+	require.Nil(t, err)
+	assert.Equal(t, 1, removed)
+
+	removed, err = store.Prune(time.Time{}, 1)
+	require.Nil(t, err)
+	assert.Equal(t, 1, removed)
+
+	entries, err = store.List()
+	require.Nil(t, err)
+	require.Len(t, entries, 1)
+	assert.True(t, third.Equal(entries[0].Date))
+}
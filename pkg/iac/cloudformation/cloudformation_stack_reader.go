@@ -0,0 +1,232 @@
+package cloudformation
+
+import (
+	"encoding/json"
+	"io"
+	"path"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/cloudskiff/driftctl/pkg/envproxy"
+	"github.com/cloudskiff/driftctl/pkg/filter"
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	"github.com/cloudskiff/driftctl/pkg/iac/terraform/state/backend"
+	"github.com/cloudskiff/driftctl/pkg/iac/terraform/state/enumerator"
+	"github.com/cloudskiff/driftctl/pkg/output"
+	"github.com/cloudskiff/driftctl/pkg/remote/aws/repository"
+	"github.com/cloudskiff/driftctl/pkg/remote/cache"
+	"github.com/cloudskiff/driftctl/pkg/resource"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const CloudformationStackReaderSupplier = "cloudformation"
+
+// BackendKeyStack reads resources of deployed stacks through the AWS API, the
+// path is a stack name or a glob pattern matching stack names
+const BackendKeyStack = "stack"
+
+var supportedBackends = []string{
+	backend.BackendKeyFile,
+	backend.BackendKeyS3,
+	backend.BackendKeyHTTP,
+	backend.BackendKeyHTTPS,
+	BackendKeyStack,
+}
+
+func IsBackendSupported(b string) bool {
+	for _, supported := range supportedBackends {
+		if supported == b {
+			return true
+		}
+	}
+	return false
+}
+
+func GetSupportedBackends() []string {
+	return supportedBackends[1:]
+}
+
+// stackResource is a resource of the output of either aws cloudformation
+// list-stack-resources or aws cloudformation describe-stack-resources
+type stackResource struct {
+	StackName          string
+	LogicalResourceId  string
+	PhysicalResourceId string
+	ResourceType       string
+	ResourceStatus     string
+}
+
+type stackResourcesExport struct {
+	StackResources         []stackResource
+	StackResourceSummaries []stackResource
+}
+
+// CloudformationStackReader reads resources managed by CloudFormation stacks
+type CloudformationStackReader struct {
+	config         config.SupplierConfig
+	backendOptions *backend.Options
+	repository     repository.CloudformationRepository
+	factory        resource.ResourceFactory
+	progress       output.Progress
+	filter         filter.Filter
+}
+
+func NewReader(config config.SupplierConfig, backendOpts *backend.Options, progress output.Progress, factory resource.ResourceFactory, filter filter.Filter) (*CloudformationStackReader, error) {
+	if !IsBackendSupported(config.Backend) {
+		return nil, errors.Errorf("Unsupported backend '%s' for CloudFormation stacks", config.Backend)
+	}
+	return &CloudformationStackReader{
+		config:         config,
+		backendOptions: backendOpts,
+		factory:        factory,
+		progress:       progress,
+		filter:         filter,
+	}, nil
+}
+
+func (r *CloudformationStackReader) Resources() ([]*resource.Resource, error) {
+	logrus.WithFields(logrus.Fields{
+		"path":    r.config.Path,
+		"backend": r.config.Backend,
+	}).Debug("Reading resources from CloudFormation stacks")
+	r.progress.Inc()
+
+	var stackResources []stackResource
+	var err error
+	if r.config.Backend == BackendKeyStack {
+		stackResources, err = r.listStacksResources()
+	} else {
+		stackResources, err = r.readExport()
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, r.config.String())
+	}
+
+	results := make([]*resource.Resource, 0, len(stackResources))
+	for _, stackRes := range stackResources {
+		if stackRes.PhysicalResourceId == "" || stackRes.ResourceStatus == "DELETE_COMPLETE" {
+			continue
+		}
+
+		ty, exist := TerraformType(stackRes.ResourceType)
+		if !exist {
+			logrus.WithFields(logrus.Fields{
+				"stack": stackRes.StackName,
+				"id":    stackRes.PhysicalResourceId,
+				"type":  stackRes.ResourceType,
+			}).Debug("Ignored CloudFormation resource without supported Terraform type")
+			continue
+		}
+
+		if r.filter != nil && r.filter.IsTypeIgnored(resource.ResourceType(ty)) {
+			logrus.WithFields(logrus.Fields{
+				"stack": stackRes.StackName,
+				"id":    stackRes.PhysicalResourceId,
+				"type":  ty,
+			}).Debug("Ignored resource from CloudFormation stack since it is ignored in filter")
+			continue
+		}
+
+		// Stacks only tell physical ids, attributes are not known and the
+		// resources cannot be compared in deep mode
+		res := r.factory.CreateAbstractResource(ty, stackRes.PhysicalResourceId, map[string]interface{}{})
+		stackName := stackRes.StackName
+		if stackName == "" {
+			stackName = r.config.String()
+		}
+		res.Source = resource.NewCloudformationStackSource(stackName, stackRes.LogicalResourceId)
+		results = append(results, res)
+	}
+
+	return results, nil
+}
+
+func (r *CloudformationStackReader) readExport() ([]stackResource, error) {
+	b, err := backend.GetBackend(r.config, r.backendOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer b.Close()
+
+	return decodeExport(b)
+}
+
+func decodeExport(reader io.Reader) ([]stackResource, error) {
+	export := stackResourcesExport{}
+	if err := json.NewDecoder(reader).Decode(&export); err != nil {
+		return nil, errors.Wrap(err, "Unable to decode CloudFormation stack resources")
+	}
+	if export.StackResources == nil && export.StackResourceSummaries == nil {
+		return nil, errors.New("given file is not an export of CloudFormation stack resources")
+	}
+	return append(export.StackResources, export.StackResourceSummaries...), nil
+}
+
+// newSession creates an AWS session with the credentials used to read states
+// from S3, DCTL_S3_ variables override AWS_ ones
+func newSession() (*session.Session, error) {
+	envProxy := envproxy.NewEnvProxy("DCTL_S3_", "AWS_")
+	envProxy.Apply()
+	defer envProxy.Restore()
+	sess, err := session.NewSessionWithOptions(session.Options{
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "Unable to create AWS session")
+	}
+	if aws.StringValue(sess.Config.Region) == "" {
+		return nil, errors.New("Unable to read CloudFormation stacks without a region, set AWS_REGION or DCTL_S3_REGION")
+	}
+	return sess, nil
+}
+
+func (r *CloudformationStackReader) listStacksResources() ([]stackResource, error) {
+	if r.repository == nil {
+		sess, err := newSession()
+		if err != nil {
+			return nil, err
+		}
+		r.repository = repository.NewCloudformationRepository(sess, cache.New(100))
+	}
+
+	pattern := r.config.Path
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, errors.Errorf("Invalid stack name pattern '%s': %s", pattern, err)
+	}
+
+	stackNames := []string{pattern}
+	if enumerator.HasMeta(pattern) {
+		stacks, err := r.repository.ListAllStacks()
+		if err != nil {
+			return nil, err
+		}
+		stackNames = make([]string, 0, len(stacks))
+		for _, stack := range stacks {
+			if match, _ := path.Match(pattern, aws.StringValue(stack.StackName)); match {
+				stackNames = append(stackNames, aws.StringValue(stack.StackName))
+			}
+		}
+		if len(stackNames) == 0 {
+			return nil, errors.Errorf("no CloudFormation stack matching %s was found", pattern)
+		}
+	}
+
+	results := make([]stackResource, 0)
+	for _, stackName := range stackNames {
+		summaries, err := r.repository.ListStackResources(stackName)
+		if err != nil {
+			return nil, err
+		}
+		for _, summary := range summaries {
+			results = append(results, stackResource{
+				StackName:          stackName,
+				LogicalResourceId:  aws.StringValue(summary.LogicalResourceId),
+				PhysicalResourceId: aws.StringValue(summary.PhysicalResourceId),
+				ResourceType:       aws.StringValue(summary.ResourceType),
+				ResourceStatus:     aws.StringValue(summary.ResourceStatus),
+			})
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,198 @@
+package cloudformation
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/cloudskiff/driftctl/pkg/filter"
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	"github.com/cloudskiff/driftctl/pkg/output"
+	"github.com/cloudskiff/driftctl/pkg/remote/aws/repository"
+	"github.com/cloudskiff/driftctl/pkg/resource"
+	"github.com/cloudskiff/driftctl/pkg/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCloudformationStackReader_Resources(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      config.SupplierConfig
+		mocks       func(repo *repository.MockCloudformationRepository)
+		ignoredType string
+		want        []*resource.Resource
+		err         string
+	}{
+		{
+			name:   "describe-stack-resources export",
+			config: config.SupplierConfig{Key: "cloudformation", Path: "testdata/describe-stack-resources.json"},
+			want: []*resource.Resource{
+				{
+					Id:     "vpc-0a1b2c3d",
+					Type:   "aws_vpc",
+					Attrs:  &resource.Attributes{},
+					Source: resource.NewCloudformationStackSource("legacy-network", "Vpc"),
+				},
+				{
+					Id:     "subnet-0a1b2c3d",
+					Type:   "aws_subnet",
+					Attrs:  &resource.Attributes{},
+					Source: resource.NewCloudformationStackSource("legacy-network", "Subnet"),
+				},
+			},
+		},
+		{
+			name:        "describe-stack-resources export with ignored type",
+			config:      config.SupplierConfig{Key: "cloudformation", Path: "testdata/describe-stack-resources.json"},
+			ignoredType: "aws_subnet",
+			want: []*resource.Resource{
+				{
+					Id:     "vpc-0a1b2c3d",
+					Type:   "aws_vpc",
+					Attrs:  &resource.Attributes{},
+					Source: resource.NewCloudformationStackSource("legacy-network", "Vpc"),
+				},
+			},
+		},
+		{
+			name:   "list-stack-resources export",
+			config: config.SupplierConfig{Key: "cloudformation", Path: "testdata/list-stack-resources.json"},
+			want: []*resource.Resource{
+				{
+					Id:     "legacy-assets",
+					Type:   "aws_s3_bucket",
+					Attrs:  &resource.Attributes{},
+					Source: resource.NewCloudformationStackSource("cloudformation://testdata/list-stack-resources.json", "Bucket"),
+				},
+			},
+		},
+		{
+			name:   "not a stack resources export",
+			config: config.SupplierConfig{Key: "cloudformation", Path: "testdata/template.json"},
+			err:    "cloudformation://testdata/template.json: given file is not an export of CloudFormation stack resources",
+		},
+		{
+			name:   "single stack",
+			config: config.SupplierConfig{Key: "cloudformation", Backend: "stack", Path: "legacy-storage"},
+			mocks: func(repo *repository.MockCloudformationRepository) {
+				repo.On("ListStackResources", "legacy-storage").Return([]*cloudformation.StackResourceSummary{
+					{LogicalResourceId: aws.String("Bucket"), PhysicalResourceId: aws.String("legacy-assets"), ResourceType: aws.String("AWS::S3::Bucket"), ResourceStatus: aws.String("CREATE_COMPLETE")},
+					{LogicalResourceId: aws.String("Topic"), PhysicalResourceId: aws.String("arn:aws:sns:us-east-1:123456789012:legacy"), ResourceType: aws.String("AWS::SNS::Topic"), ResourceStatus: aws.String("UPDATE_COMPLETE")},
+				}, nil)
+			},
+			want: []*resource.Resource{
+				{
+					Id:     "legacy-assets",
+					Type:   "aws_s3_bucket",
+					Attrs:  &resource.Attributes{},
+					Source: resource.NewCloudformationStackSource("legacy-storage", "Bucket"),
+				},
+				{
+					Id:     "arn:aws:sns:us-east-1:123456789012:legacy",
+					Type:   "aws_sns_topic",
+					Attrs:  &resource.Attributes{},
+					Source: resource.NewCloudformationStackSource("legacy-storage", "Topic"),
+				},
+			},
+		},
+		{
+			name:   "stacks matching a pattern",
+			config: config.SupplierConfig{Key: "cloudformation", Backend: "stack", Path: "legacy-*"},
+			mocks: func(repo *repository.MockCloudformationRepository) {
+				repo.On("ListAllStacks").Return([]*cloudformation.Stack{
+					{StackName: aws.String("legacy-network")},
+					{StackName: aws.String("eks-cluster")},
+					{StackName: aws.String("legacy-storage")},
+				}, nil)
+				repo.On("ListStackResources", "legacy-network").Return([]*cloudformation.StackResourceSummary{
+					{LogicalResourceId: aws.String("Vpc"), PhysicalResourceId: aws.String("vpc-0a1b2c3d"), ResourceType: aws.String("AWS::EC2::VPC"), ResourceStatus: aws.String("CREATE_COMPLETE")},
+				}, nil)
+				repo.On("ListStackResources", "legacy-storage").Return([]*cloudformation.StackResourceSummary{
+					{LogicalResourceId: aws.String("Bucket"), PhysicalResourceId: aws.String("legacy-assets"), ResourceType: aws.String("AWS::S3::Bucket"), ResourceStatus: aws.String("CREATE_COMPLETE")},
+				}, nil)
+			},
+			want: []*resource.Resource{
+				{
+					Id:     "vpc-0a1b2c3d",
+					Type:   "aws_vpc",
+					Attrs:  &resource.Attributes{},
+					Source: resource.NewCloudformationStackSource("legacy-network", "Vpc"),
+				},
+				{
+					Id:     "legacy-assets",
+					Type:   "aws_s3_bucket",
+					Attrs:  &resource.Attributes{},
+					Source: resource.NewCloudformationStackSource("legacy-storage", "Bucket"),
+				},
+			},
+		},
+		{
+			name:   "no stack matching a pattern",
+			config: config.SupplierConfig{Key: "cloudformation", Backend: "stack", Path: "legacy-*"},
+			mocks: func(repo *repository.MockCloudformationRepository) {
+				repo.On("ListAllStacks").Return([]*cloudformation.Stack{
+					{StackName: aws.String("eks-cluster")},
+				}, nil)
+			},
+			err: "cloudformation+stack://legacy-*: no CloudFormation stack matching legacy-* was found",
+		},
+		{
+			name:   "stack listing error",
+			config: config.SupplierConfig{Key: "cloudformation", Backend: "stack", Path: "legacy-storage"},
+			mocks: func(repo *repository.MockCloudformationRepository) {
+				repo.On("ListStackResources", "legacy-storage").Return(nil, errors.New("Stack with id legacy-storage does not exist"))
+			},
+			err: "cloudformation+stack://legacy-storage: Stack with id legacy-storage does not exist",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			progress := &output.MockProgress{}
+			progress.On("Inc").Return().Times(1)
+
+			testFilter := &filter.MockFilter{}
+			testFilter.On("IsTypeIgnored", mock.Anything).Return(func(ty resource.ResourceType) bool {
+				return ty.String() == tt.ignoredType
+			})
+
+			factory := terraform.NewTerraformResourceFactory(resource.NewSchemaRepository())
+			r, err := NewReader(tt.config, nil, progress, factory, testFilter)
+			assert.NoError(t, err)
+
+			repo := &repository.MockCloudformationRepository{}
+			if tt.mocks != nil {
+				tt.mocks(repo)
+			}
+			r.repository = repo
+
+			got, err := r.Resources()
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCloudformationStackReader_UnsupportedBackend(t *testing.T) {
+	_, err := NewReader(config.SupplierConfig{Key: "cloudformation", Backend: "tfcloud", Path: "workspace"}, nil, nil, nil, nil)
+	assert.EqualError(t, err, "Unsupported backend 'tfcloud' for CloudFormation stacks")
+}
+
+func TestNewSessionWithEnvProxy(t *testing.T) {
+	os.Setenv("AWS_DEFAULT_REGION", "us-east-1")
+	os.Setenv("DCTL_S3_DEFAULT_REGION", "eu-west-3")
+	defer os.Unsetenv("AWS_DEFAULT_REGION")
+	defer os.Unsetenv("DCTL_S3_DEFAULT_REGION")
+
+	sess, err := newSession()
+	assert.NoError(t, err)
+	assert.Equal(t, "eu-west-3", aws.StringValue(sess.Config.Region))
+	assert.Equal(t, "us-east-1", os.Getenv("AWS_DEFAULT_REGION"))
+}
@@ -0,0 +1,51 @@
+package cloudformation
+
+import "github.com/cloudskiff/driftctl/pkg/resource"
+
+// terraformTypes maps CloudFormation types to Terraform types of resources
+// whose physical id is also their Terraform id
+var terraformTypes = map[string]string{
+	"AWS::ApiGateway::ApiKey":         "aws_api_gateway_api_key",
+	"AWS::ApiGateway::RestApi":        "aws_api_gateway_rest_api",
+	"AWS::CloudFormation::Stack":      "aws_cloudformation_stack",
+	"AWS::CloudFront::Distribution":   "aws_cloudfront_distribution",
+	"AWS::DynamoDB::Table":            "aws_dynamodb_table",
+	"AWS::EC2::Instance":              "aws_instance",
+	"AWS::EC2::InternetGateway":       "aws_internet_gateway",
+	"AWS::EC2::KeyPair":               "aws_key_pair",
+	"AWS::EC2::NatGateway":            "aws_nat_gateway",
+	"AWS::EC2::NetworkAcl":            "aws_network_acl",
+	"AWS::EC2::RouteTable":            "aws_route_table",
+	"AWS::EC2::SecurityGroup":         "aws_security_group",
+	"AWS::EC2::Subnet":                "aws_subnet",
+	"AWS::EC2::Volume":                "aws_ebs_volume",
+	"AWS::EC2::VPC":                   "aws_vpc",
+	"AWS::ECR::Repository":            "aws_ecr_repository",
+	"AWS::IAM::AccessKey":             "aws_iam_access_key",
+	"AWS::IAM::ManagedPolicy":         "aws_iam_policy",
+	"AWS::IAM::Role":                  "aws_iam_role",
+	"AWS::IAM::User":                  "aws_iam_user",
+	"AWS::KMS::Alias":                 "aws_kms_alias",
+	"AWS::KMS::Key":                   "aws_kms_key",
+	"AWS::Lambda::EventSourceMapping": "aws_lambda_event_source_mapping",
+	"AWS::Lambda::Function":           "aws_lambda_function",
+	"AWS::RDS::DBCluster":             "aws_rds_cluster",
+	"AWS::RDS::DBInstance":            "aws_db_instance",
+	"AWS::RDS::DBSubnetGroup":         "aws_db_subnet_group",
+	"AWS::Route53::HealthCheck":       "aws_route53_health_check",
+	"AWS::Route53::HostedZone":        "aws_route53_zone",
+	"AWS::S3::Bucket":                 "aws_s3_bucket",
+	"AWS::SNS::Subscription":          "aws_sns_topic_subscription",
+	"AWS::SNS::Topic":                 "aws_sns_topic",
+	"AWS::SQS::Queue":                 "aws_sqs_queue",
+}
+
+// TerraformType returns the Terraform type of a CloudFormation resource type,
+// when it is supported
+func TerraformType(cfnType string) (string, bool) {
+	ty, exist := terraformTypes[cfnType]
+	if !exist || !resource.IsResourceTypeSupported(ty) {
+		return "", false
+	}
+	return ty, true
+}
@@ -0,0 +1,193 @@
+package pulumi
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/cloudskiff/driftctl/pkg/filter"
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	"github.com/cloudskiff/driftctl/pkg/iac/terraform/state/backend"
+	"github.com/cloudskiff/driftctl/pkg/output"
+	"github.com/cloudskiff/driftctl/pkg/resource"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+const PulumiStackReaderSupplier = "pulumi"
+
+// Only backends serving a single JSON document can hold a stack
+var supportedBackends = []string{
+	backend.BackendKeyFile,
+	backend.BackendKeyS3,
+	backend.BackendKeyGS,
+	backend.BackendKeyAzureRM,
+	backend.BackendKeyHTTP,
+	backend.BackendKeyHTTPS,
+}
+
+func IsBackendSupported(b string) bool {
+	for _, supported := range supportedBackends {
+		if supported == b {
+			return true
+		}
+	}
+	return false
+}
+
+func GetSupportedBackends() []string {
+	return supportedBackends[1:]
+}
+
+// deployment is the part of a Pulumi stack we care about. It is found under
+// the deployment key of pulumi stack export outputs and under checkpoint.latest
+// in stack files of self-managed backends.
+type deployment struct {
+	Resources []stackResource `json:"resources"`
+}
+
+type stackResource struct {
+	Urn     string                 `json:"urn"`
+	Custom  bool                   `json:"custom"`
+	Delete  bool                   `json:"delete"`
+	Id      string                 `json:"id"`
+	Type    string                 `json:"type"`
+	Outputs map[string]interface{} `json:"outputs"`
+}
+
+type stack struct {
+	Deployment *deployment `json:"deployment"`
+	Checkpoint *struct {
+		Latest *deployment `json:"latest"`
+	} `json:"checkpoint"`
+}
+
+// PulumiStackReader reads resources of Terraform bridged providers from a
+// Pulumi stack
+type PulumiStackReader struct {
+	config                   config.SupplierConfig
+	backendOptions           *backend.Options
+	deserializer             *resource.Deserializer
+	resourceSchemaRepository resource.SchemaRepositoryInterface
+	progress                 output.Progress
+	filter                   filter.Filter
+}
+
+func NewReader(config config.SupplierConfig, backendOpts *backend.Options, progress output.Progress, deserializer *resource.Deserializer, resourceSchemaRepository resource.SchemaRepositoryInterface, filter filter.Filter) (*PulumiStackReader, error) {
+	if !IsBackendSupported(config.Backend) {
+		return nil, errors.Errorf("Unsupported backend '%s' for Pulumi stacks", config.Backend)
+	}
+	return &PulumiStackReader{
+		config:                   config,
+		backendOptions:           backendOpts,
+		deserializer:             deserializer,
+		resourceSchemaRepository: resourceSchemaRepository,
+		progress:                 progress,
+		filter:                   filter,
+	}, nil
+}
+
+func (r *PulumiStackReader) Resources() ([]*resource.Resource, error) {
+	logrus.WithFields(logrus.Fields{
+		"path":    r.config.Path,
+		"backend": r.config.Backend,
+	}).Debug("Reading resources from Pulumi stack")
+	r.progress.Inc()
+
+	b, err := backend.GetBackend(r.config, r.backendOptions)
+	if err != nil {
+		return nil, errors.Wrap(err, r.config.String())
+	}
+	defer b.Close()
+
+	d, err := readDeployment(b)
+	if err != nil {
+		return nil, errors.Wrap(err, r.config.String())
+	}
+
+	results := make([]*resource.Resource, 0, len(d.Resources))
+	for _, stackRes := range d.Resources {
+		// Components and providers do not exist in the cloud, resources pending
+		// deletion are already replaced
+		if !stackRes.Custom || stackRes.Delete || stackRes.Id == "" {
+			continue
+		}
+
+		ty, ok := TerraformType(stackRes.Type)
+		if !ok {
+			logrus.WithFields(logrus.Fields{
+				"urn":  stackRes.Urn,
+				"type": stackRes.Type,
+			}).Debug("Ignored Pulumi resource without supported Terraform type")
+			continue
+		}
+
+		if r.filter != nil && r.filter.IsTypeIgnored(resource.ResourceType(ty)) {
+			logrus.WithFields(logrus.Fields{
+				"urn":  stackRes.Urn,
+				"type": ty,
+			}).Debug("Ignored resource from Pulumi stack since it is ignored in filter")
+			continue
+		}
+
+		// Resources of other providers than the scanned one have no schema
+		schema, _ := r.resourceSchemaRepository.GetSchema(ty)
+		attrs := terraformAttributes(stackRes.Outputs, schema)
+		attrs["id"] = stackRes.Id
+
+		res, err := r.deserialize(ty, attrs)
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s: %s", r.config.String(), stackRes.Urn)
+		}
+		stackName, name := parseUrn(stackRes.Urn)
+		source := resource.NewTerraformStateSource(r.config.String(), "", name)
+		source.WorkspaceName = stackName
+		res.Source = source
+		results = append(results, res)
+	}
+
+	return results, nil
+}
+
+// deserialize converts attributes to a cty value, as the deserializer expects
+// values decoded from states
+func (r *PulumiStackReader) deserialize(ty string, attrs map[string]interface{}) (*resource.Resource, error) {
+	raw, err := json.Marshal(attrs)
+	if err != nil {
+		return nil, err
+	}
+	impliedType, err := ctyjson.ImpliedType(raw)
+	if err != nil {
+		return nil, err
+	}
+	val, err := ctyjson.Unmarshal(raw, impliedType)
+	if err != nil {
+		return nil, err
+	}
+	return r.deserializer.DeserializeOne(ty, val)
+}
+
+func readDeployment(reader io.Reader) (*deployment, error) {
+	s := stack{}
+	if err := json.NewDecoder(reader).Decode(&s); err != nil {
+		return nil, errors.Wrap(err, "Unable to decode Pulumi stack")
+	}
+	if s.Deployment != nil {
+		return s.Deployment, nil
+	}
+	if s.Checkpoint != nil && s.Checkpoint.Latest != nil {
+		return s.Checkpoint.Latest, nil
+	}
+	return nil, errors.New("given file is not a Pulumi stack export nor a Pulumi stack checkpoint")
+}
+
+// parseUrn returns the stack and the name of a resource from its URN, e.g.
+// urn:pulumi:dev::my-project::aws:s3/bucket:Bucket::my-bucket
+func parseUrn(urn string) (string, string) {
+	parts := strings.Split(urn, "::")
+	if len(parts) < 4 {
+		return "", urn
+	}
+	return strings.TrimPrefix(parts[0], "urn:pulumi:"), parts[len(parts)-1]
+}
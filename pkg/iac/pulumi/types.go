@@ -0,0 +1,213 @@
+package pulumi
+
+import (
+	"encoding/json"
+	"strings"
+	"unicode"
+
+	"github.com/cloudskiff/driftctl/pkg/resource"
+)
+
+// providerPrefixes maps Pulumi packages bridged from Terraform providers to
+// the Terraform type prefix of their resources
+var providerPrefixes = map[string]string{
+	"aws":    "aws",
+	"gcp":    "google",
+	"azure":  "azurerm",
+	"github": "github",
+}
+
+// typeOverrides lists Pulumi types whose Terraform type cannot be guessed
+// from the Pulumi module and resource names
+var typeOverrides = map[string]string{
+	"aws:rds/instance:Instance":                "aws_db_instance",
+	"aws:rds/subnetGroup:SubnetGroup":          "aws_db_subnet_group",
+	"gcp:cloudrun/service:Service":             "google_cloud_run_service",
+	"gcp:projects/iAMBinding:IAMBinding":       "google_project_iam_binding",
+	"gcp:projects/iAMMember:IAMMember":         "google_project_iam_member",
+	"gcp:projects/iAMPolicy:IAMPolicy":         "google_project_iam_policy",
+	"azure:containerservice/registry:Registry": "azurerm_container_registry",
+	"azure:lb/loadBalancer:LoadBalancer":       "azurerm_lb",
+	"azure:privatedns/zone:Zone":               "azurerm_private_dns_zone",
+	"azure:privatedns/aRecord:ARecord":         "azurerm_private_dns_a_record",
+	"azure:privatedns/aAAARecord:AAAARecord":   "azurerm_private_dns_aaaa_record",
+	"azure:privatedns/cnameRecord:CnameRecord": "azurerm_private_dns_cname_record",
+	"azure:privatedns/pTRRecord:PTRRecord":     "azurerm_private_dns_ptr_record",
+}
+
+// mapProperties holds user defined maps, their keys are kept as is
+var mapProperties = map[string]struct{}{
+	"tags":    {},
+	"tagsAll": {},
+	"labels":  {},
+}
+
+// secretSignature marks secret values in Pulumi stacks
+const secretSignature = "4dabf18193072939515e22adb298388d"
+
+// TerraformType returns the Terraform resource type of a Pulumi resource type
+// token (e.g. aws:s3/bucket:Bucket is aws_s3_bucket and aws:ec2/vpc:Vpc is
+// aws_vpc), when the resource type is supported
+func TerraformType(token string) (string, bool) {
+	if ty, exist := typeOverrides[token]; exist {
+		return ty, resource.IsResourceTypeSupported(ty)
+	}
+
+	parts := strings.Split(token, ":")
+	if len(parts) != 3 {
+		return "", false
+	}
+	prefix, exist := providerPrefixes[parts[0]]
+	if !exist {
+		return "", false
+	}
+	module := strings.ToLower(strings.SplitN(parts[1], "/", 2)[0])
+	name := toSnakeCase(parts[2])
+
+	candidates := []string{
+		strings.Join([]string{prefix, module, name}, "_"),
+		strings.Join([]string{prefix, name}, "_"),
+	}
+	for _, ty := range candidates {
+		if resource.IsResourceTypeSupported(ty) {
+			return ty, true
+		}
+	}
+	return "", false
+}
+
+// terraformAttributes converts Pulumi outputs to Terraform attributes of the
+// given schema. Bridged providers rename attributes to camel case, pluralize
+// names of repeated blocks (e.g. lifecycle_rule is lifecycleRules) and flatten
+// blocks holding a single item, so names are looked up in the schema, blocks
+// are turned back into lists and outputs unknown to the schema are dropped.
+// Without schema, names are only converted to snake case.
+func terraformAttributes(outputs map[string]interface{}, schema *resource.Schema) map[string]interface{} {
+	return convertObject(outputs, schema, "")
+}
+
+func convertObject(object map[string]interface{}, schema *resource.Schema, path string) map[string]interface{} {
+	attrs := make(map[string]interface{}, len(object))
+	for key, val := range object {
+		name := toSnakeCase(key)
+		if schema == nil {
+			if _, isMap := mapProperties[key]; isMap {
+				attrs[name] = plainValue(val)
+				continue
+			}
+			attrs[name] = convertValue(val, nil, "")
+			continue
+		}
+
+		name, known := schemaName(schema, path, name)
+		if !known {
+			continue
+		}
+		attrPath := joinPath(path, name)
+		if !isBlock(schema, attrPath) {
+			// Attributes like tags hold user defined keys, kept as is
+			attrs[name] = plainValue(val)
+			continue
+		}
+		block := convertValue(val, schema, attrPath)
+		if item, isObject := block.(map[string]interface{}); isObject {
+			block = []interface{}{item}
+		}
+		attrs[name] = block
+	}
+	return attrs
+}
+
+func convertValue(value interface{}, schema *resource.Schema, path string) interface{} {
+	switch v := plainValue(value).(type) {
+	case map[string]interface{}:
+		return convertObject(v, schema, path)
+	case []interface{}:
+		items := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			items = append(items, convertValue(item, schema, path))
+		}
+		return items
+	default:
+		return v
+	}
+}
+
+// schemaName returns the schema name of an attribute, trying singular forms
+// of plural names of repeated blocks
+func schemaName(schema *resource.Schema, path, name string) (string, bool) {
+	candidates := []string{name}
+	if strings.HasSuffix(name, "ies") {
+		candidates = append(candidates, strings.TrimSuffix(name, "ies")+"y")
+	}
+	if strings.HasSuffix(name, "es") {
+		candidates = append(candidates, strings.TrimSuffix(name, "es"))
+	}
+	if strings.HasSuffix(name, "s") {
+		candidates = append(candidates, strings.TrimSuffix(name, "s"))
+	}
+	for _, candidate := range candidates {
+		attrPath := joinPath(path, candidate)
+		if _, exist := schema.Attributes[attrPath]; exist || isBlock(schema, attrPath) {
+			return candidate, true
+		}
+	}
+	return "", false
+}
+
+// isBlock returns true when the schema holds attributes nested under the path
+func isBlock(schema *resource.Schema, path string) bool {
+	prefix := path + "."
+	for attrPath := range schema.Attributes {
+		if strings.HasPrefix(attrPath, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// plainValue returns the plain text of secrets exported with --show-secrets,
+// encrypted secrets are dropped and other values are returned as is
+func plainValue(value interface{}) interface{} {
+	secret, ok := value.(map[string]interface{})
+	if !ok {
+		return value
+	}
+	if _, isSecret := secret[secretSignature]; !isSecret {
+		return value
+	}
+	plaintext, ok := secret["plaintext"].(string)
+	if !ok {
+		return nil
+	}
+	var plain interface{}
+	if err := json.Unmarshal([]byte(plaintext), &plain); err != nil {
+		return nil
+	}
+	return plain
+}
+
+// toSnakeCase converts camel case names, acronyms are kept together, e.g.
+// BucketIAMMember is bucket_iam_member
+func toSnakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 {
+			prev := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextIsLower) {
+				b.WriteRune('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
@@ -0,0 +1,75 @@
+package pulumi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTerraformType(t *testing.T) {
+	tests := []struct {
+		token string
+		want  string
+		ok    bool
+	}{
+		{token: "aws:s3/bucket:Bucket", want: "aws_s3_bucket", ok: true},
+		{token: "aws:s3/bucketPolicy:BucketPolicy", want: "aws_s3_bucket_policy", ok: true},
+		{token: "aws:ec2/instance:Instance", want: "aws_instance", ok: true},
+		{token: "aws:ec2/securityGroup:SecurityGroup", want: "aws_security_group", ok: true},
+		{token: "aws:rds/instance:Instance", want: "aws_db_instance", ok: true},
+		{token: "aws:route53/zone:Zone", want: "aws_route53_zone", ok: true},
+		{token: "gcp:storage/bucketIAMMember:BucketIAMMember", want: "google_storage_bucket_iam_member", ok: true},
+		{token: "gcp:compute/network:Network", want: "google_compute_network", ok: true},
+		{token: "gcp:projects/iAMMember:IAMMember", want: "google_project_iam_member", ok: true},
+		{token: "azure:core/resourceGroup:ResourceGroup", want: "azurerm_resource_group", ok: true},
+		{token: "azure:network/virtualNetwork:VirtualNetwork", want: "azurerm_virtual_network", ok: true},
+		{token: "github:index/teamMembership:TeamMembership", want: "github_team_membership", ok: true},
+		{token: "aws:ec2/unknown:Unknown"},
+		{token: "kubernetes:core/v1:Namespace"},
+		{token: "azure-native:resources:ResourceGroup"},
+		{token: "pulumi:providers:aws"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.token, func(t *testing.T) {
+			got, ok := TerraformType(tt.token)
+			assert.Equal(t, tt.ok, ok)
+			if tt.ok {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestToSnakeCase(t *testing.T) {
+	tests := map[string]string{
+		"bucket":                      "bucket",
+		"Bucket":                      "bucket",
+		"cidrBlock":                   "cidr_block",
+		"ipv6CidrBlock":               "ipv6_cidr_block",
+		"BucketIAMMember":             "bucket_iam_member",
+		"IAMMember":                   "iam_member",
+		"ARecord":                     "a_record",
+		"enableDnsHostnames":          "enable_dns_hostnames",
+		"assignIpv6AddressOnCreation": "assign_ipv6_address_on_creation",
+	}
+	for name, want := range tests {
+		assert.Equal(t, want, toSnakeCase(name), name)
+	}
+}
+
+func TestTerraformAttributes_Secrets(t *testing.T) {
+	got := terraformAttributes(map[string]interface{}{
+		"password": map[string]interface{}{
+			secretSignature: "1b47061264138c4ac30d75fd1eb44270",
+			"plaintext":     `{"nestedValue":"s3cr3t"}`,
+		},
+		"encrypted": map[string]interface{}{
+			secretSignature: "1b47061264138c4ac30d75fd1eb44270",
+			"ciphertext":    "v1:AAAA:BBBB",
+		},
+	}, nil)
+	assert.Equal(t, map[string]interface{}{
+		"password":  map[string]interface{}{"nested_value": "s3cr3t"},
+		"encrypted": nil,
+	}, got)
+}
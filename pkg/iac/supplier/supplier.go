@@ -5,6 +5,9 @@ import (
 
 	"github.com/cloudskiff/driftctl/pkg/alerter"
 	"github.com/cloudskiff/driftctl/pkg/filter"
+	"github.com/cloudskiff/driftctl/pkg/iac/cloudformation"
+	"github.com/cloudskiff/driftctl/pkg/iac/pulumi"
+	"github.com/cloudskiff/driftctl/pkg/iac/terraform/plan"
 	"github.com/cloudskiff/driftctl/pkg/iac/terraform/state/backend"
 	"github.com/cloudskiff/driftctl/pkg/output"
 	"github.com/cloudskiff/driftctl/pkg/terraform"
@@ -20,6 +23,9 @@ import (
 
 var supportedSuppliers = []string{
 	state.TerraformStateReaderSupplier,
+	pulumi.PulumiStackReaderSupplier,
+	cloudformation.CloudformationStackReaderSupplier,
+	plan.TerraformPlanReaderSupplier,
 }
 
 func IsSupplierSupported(supplierKey string) bool {
@@ -37,6 +43,7 @@ func GetIACSupplier(configs []config.SupplierConfig,
 	progress output.Progress,
 	alerter *alerter.Alerter,
 	factory resource.ResourceFactory,
+	resourceSchemaRepository resource.SchemaRepositoryInterface,
 	filter filter.Filter) (resource.Supplier, error) {
 
 	chainSupplier := NewIacChainSupplier()
@@ -52,6 +59,12 @@ func GetIACSupplier(configs []config.SupplierConfig,
 		switch config.Key {
 		case state.TerraformStateReaderSupplier:
 			supplier, err = state.NewReader(config, library, backendOpts, progress, alerter, deserializer, filter)
+		case pulumi.PulumiStackReaderSupplier:
+			supplier, err = pulumi.NewReader(config, backendOpts, progress, deserializer, resourceSchemaRepository, filter)
+		case cloudformation.CloudformationStackReaderSupplier:
+			supplier, err = cloudformation.NewReader(config, backendOpts, progress, factory, filter)
+		case plan.TerraformPlanReaderSupplier:
+			supplier, err = plan.NewReader(config, backendOpts, progress, deserializer, filter)
 		default:
 			return nil, errors.Errorf("Unsupported supplier '%s'", config.Key)
 		}
@@ -75,12 +88,38 @@ func GetSupportedSuppliers() []string {
 	return supportedSuppliers
 }
 
-func GetSupportedSchemes() []string {
-	schemes := []string{
-		"tfstate://",
+// GetSupportedBackends returns backends a supplier can read from
+func GetSupportedBackends(supplierKey string) []string {
+	switch supplierKey {
+	case pulumi.PulumiStackReaderSupplier:
+		return pulumi.GetSupportedBackends()
+	case cloudformation.CloudformationStackReaderSupplier:
+		return cloudformation.GetSupportedBackends()
+	case plan.TerraformPlanReaderSupplier:
+		return plan.GetSupportedBackends()
+	default:
+		return backend.GetSupportedBackends()
+	}
+}
+
+func IsBackendSupported(supplierKey, backendKey string) bool {
+	switch supplierKey {
+	case pulumi.PulumiStackReaderSupplier:
+		return pulumi.IsBackendSupported(backendKey)
+	case cloudformation.CloudformationStackReaderSupplier:
+		return cloudformation.IsBackendSupported(backendKey)
+	case plan.TerraformPlanReaderSupplier:
+		return plan.IsBackendSupported(backendKey)
+	default:
+		return backend.IsSupported(backendKey)
 	}
+}
+
+func GetSupportedSchemes() []string {
+	schemes := make([]string, 0)
 	for _, supplier := range supportedSuppliers {
-		for _, backend := range backend.GetSupportedBackends() {
+		schemes = append(schemes, fmt.Sprintf("%s://", supplier))
+		for _, backend := range GetSupportedBackends(supplier) {
 			schemes = append(schemes, fmt.Sprintf("%s+%s://", supplier, backend))
 		}
 	}
@@ -69,6 +69,18 @@ func TestGetIACSupplier(t *testing.T) {
 			},
 			wantErr: nil,
 		},
+		{
+			name: "test unsupported backend for pulumi",
+			args: args{
+				config: []config.SupplierConfig{
+					{Key: "pulumi", Backend: "tfcloud", Path: "workspace_id"},
+				},
+				options: &backend.Options{
+					Headers: map[string]string{},
+				},
+			},
+			wantErr: fmt.Errorf("Unsupported backend 'tfcloud' for Pulumi stacks"),
+		},
 		{
 			name: "test valid multiples states",
 			args: args{
@@ -95,7 +107,7 @@ func TestGetIACSupplier(t *testing.T) {
 
 			testFilter := &filter.MockFilter{}
 
-			_, err := GetIACSupplier(tt.args.config, terraform.NewProviderLibrary(), tt.args.options, progress, alerter, factory, testFilter)
+			_, err := GetIACSupplier(tt.args.config, terraform.NewProviderLibrary(), tt.args.options, progress, alerter, factory, repo, testFilter)
 
 			if tt.wantErr != nil && err.Error() != tt.wantErr.Error() {
 				t.Errorf("GetIACSupplier() error = %v, wantErr %v", err, tt.wantErr)
@@ -110,9 +122,29 @@ func TestGetSupportedSchemes(t *testing.T) {
 	want := []string{
 		"tfstate://",
 		"tfstate+s3://",
+		"tfstate+gs://",
+		"tfstate+azurerm://",
 		"tfstate+http://",
 		"tfstate+https://",
 		"tfstate+tfcloud://",
+		"tfstate+workdir://",
+		"pulumi://",
+		"pulumi+s3://",
+		"pulumi+gs://",
+		"pulumi+azurerm://",
+		"pulumi+http://",
+		"pulumi+https://",
+		"cloudformation://",
+		"cloudformation+s3://",
+		"cloudformation+http://",
+		"cloudformation+https://",
+		"cloudformation+stack://",
+		"tfplan://",
+		"tfplan+s3://",
+		"tfplan+gs://",
+		"tfplan+azurerm://",
+		"tfplan+http://",
+		"tfplan+https://",
 	}
 
 	if got := GetSupportedSchemes(); !reflect.DeepEqual(got, want) {
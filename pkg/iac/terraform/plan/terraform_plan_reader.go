@@ -0,0 +1,175 @@
+package plan
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/cloudskiff/driftctl/pkg/filter"
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	"github.com/cloudskiff/driftctl/pkg/iac/terraform/state/backend"
+	"github.com/cloudskiff/driftctl/pkg/output"
+	"github.com/cloudskiff/driftctl/pkg/resource"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+const TerraformPlanReaderSupplier = "tfplan"
+
+// Only backends serving a single JSON document can hold a plan
+var supportedBackends = []string{
+	backend.BackendKeyFile,
+	backend.BackendKeyS3,
+	backend.BackendKeyGS,
+	backend.BackendKeyAzureRM,
+	backend.BackendKeyHTTP,
+	backend.BackendKeyHTTPS,
+}
+
+func IsBackendSupported(b string) bool {
+	for _, supported := range supportedBackends {
+		if supported == b {
+			return true
+		}
+	}
+	return false
+}
+
+func GetSupportedBackends() []string {
+	return supportedBackends[1:]
+}
+
+// TerraformPlanReader reads resources expected once a Terraform plan is
+// applied, from the output of terraform show -json
+type TerraformPlanReader struct {
+	config         config.SupplierConfig
+	backendOptions *backend.Options
+	deserializer   *resource.Deserializer
+	progress       output.Progress
+	filter         filter.Filter
+}
+
+func NewReader(config config.SupplierConfig, backendOpts *backend.Options, progress output.Progress, deserializer *resource.Deserializer, filter filter.Filter) (*TerraformPlanReader, error) {
+	if !IsBackendSupported(config.Backend) {
+		return nil, errors.Errorf("Unsupported backend '%s' for Terraform plans", config.Backend)
+	}
+	return &TerraformPlanReader{
+		config:         config,
+		backendOptions: backendOpts,
+		deserializer:   deserializer,
+		progress:       progress,
+		filter:         filter,
+	}, nil
+}
+
+func (r *TerraformPlanReader) Resources() ([]*resource.Resource, error) {
+	logrus.WithFields(logrus.Fields{
+		"path":    r.config.Path,
+		"backend": r.config.Backend,
+	}).Debug("Reading resources from Terraform plan")
+	r.progress.Inc()
+
+	b, err := backend.GetBackend(r.config, r.backendOptions)
+	if err != nil {
+		return nil, errors.Wrap(err, r.config.String())
+	}
+	defer b.Close()
+
+	p, err := readPlan(b)
+	if err != nil {
+		return nil, errors.Wrap(err, r.config.String())
+	}
+
+	priorValues := make(map[string]map[string]interface{})
+	if p.PriorState != nil {
+		for _, res := range p.PriorState.Values.RootModule.resources() {
+			priorValues[res.Address] = res.AttributeValues
+		}
+	}
+
+	results := make([]*resource.Resource, 0, len(p.ResourceChanges))
+	for _, change := range p.ResourceChanges {
+		if change.Mode != ModeManaged {
+			continue
+		}
+
+		if !resource.IsResourceTypeSupported(change.Type) {
+			logrus.WithFields(logrus.Fields{
+				"address": change.Address,
+				"type":    change.Type,
+			}).Debug("Ignored unsupported resource from plan")
+			continue
+		}
+
+		if r.filter != nil && r.filter.IsTypeIgnored(resource.ResourceType(change.Type)) {
+			logrus.WithFields(logrus.Fields{
+				"address": change.Address,
+				"type":    change.Type,
+			}).Debug("Ignored resource from plan since it is ignored in filter")
+			continue
+		}
+
+		// Created resources, including replaced ones, are expected with their
+		// planned values, deleted resources are not expected anymore and other
+		// resources are expected as they are in the prior state
+		var values map[string]interface{}
+		switch {
+		case change.Change.hasAction(ActionCreate):
+			values = change.Change.After
+		case change.Change.hasAction(ActionDelete):
+			continue
+		default:
+			values = priorValues[change.Address]
+			if values == nil {
+				values = change.Change.Before
+			}
+		}
+
+		// Ids of most created resources are only known after apply
+		if id, _ := values["id"].(string); id == "" {
+			logrus.WithFields(logrus.Fields{
+				"address": change.Address,
+				"actions": change.Change.Actions,
+			}).Debug("Ignored resource from plan since its id is unknown")
+			continue
+		}
+
+		res, err := r.deserialize(change.Type, values)
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s: %s", r.config.String(), change.Address)
+		}
+		res.Source = resource.NewTerraformStateSource(r.config.String(), change.ModuleAddress, change.Name)
+		results = append(results, res)
+	}
+
+	return results, nil
+}
+
+// deserialize converts planned values to a cty value, as the deserializer
+// expects values decoded from states
+func (r *TerraformPlanReader) deserialize(ty string, values map[string]interface{}) (*resource.Resource, error) {
+	raw, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+	impliedType, err := ctyjson.ImpliedType(raw)
+	if err != nil {
+		return nil, err
+	}
+	val, err := ctyjson.Unmarshal(raw, impliedType)
+	if err != nil {
+		return nil, err
+	}
+	return r.deserializer.DeserializeOne(ty, val)
+}
+
+func readPlan(reader io.Reader) (*Plan, error) {
+	p := Plan{}
+	if err := json.NewDecoder(reader).Decode(&p); err != nil {
+		return nil, errors.Wrap(err, "Unable to decode Terraform plan")
+	}
+	if p.FormatVersion == "" {
+		return nil, errors.New("given file is not a Terraform JSON plan, use terraform show -json to convert it")
+	}
+	return &p, nil
+}
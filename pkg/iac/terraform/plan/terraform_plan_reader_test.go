@@ -0,0 +1,104 @@
+package plan
+
+import (
+	"testing"
+
+	"github.com/cloudskiff/driftctl/pkg/filter"
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	"github.com/cloudskiff/driftctl/pkg/output"
+	"github.com/cloudskiff/driftctl/pkg/resource"
+	"github.com/cloudskiff/driftctl/pkg/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTerraformPlanReader_Resources(t *testing.T) {
+	bucket := &resource.Resource{
+		Id:   "assets-1a2b3c4",
+		Type: "aws_s3_bucket",
+		Attrs: &resource.Attributes{
+			"id":            "assets-1a2b3c4",
+			"bucket":        "assets-1a2b3c4",
+			"force_destroy": false,
+		},
+		Source: &resource.TerraformStateSource{State: "tfplan://testdata/plan.json", Name: "assets"},
+	}
+	vpc := &resource.Resource{
+		Id:   "vpc-0a1b2c3d",
+		Type: "aws_vpc",
+		Attrs: &resource.Attributes{
+			"id":         "vpc-0a1b2c3d",
+			"cidr_block": "10.0.0.0/16",
+		},
+		Source: &resource.TerraformStateSource{State: "tfplan://testdata/plan.json", Module: "module.network", Name: "main"},
+	}
+	keyPair := &resource.Resource{
+		Id:   "deployer",
+		Type: "aws_key_pair",
+		Attrs: &resource.Attributes{
+			"id":         "deployer",
+			"key_name":   "deployer",
+			"public_key": "ssh-rsa new",
+		},
+		Source: &resource.TerraformStateSource{State: "tfplan://testdata/plan.json", Name: "deployer"},
+	}
+
+	tests := []struct {
+		name        string
+		path        string
+		ignoredType string
+		want        []*resource.Resource
+		err         string
+	}{
+		{
+			name: "plan",
+			path: "testdata/plan.json",
+			want: []*resource.Resource{bucket, vpc, keyPair},
+		},
+		{
+			name:        "plan with ignored type",
+			path:        "testdata/plan.json",
+			ignoredType: "aws_vpc",
+			want:        []*resource.Resource{bucket, keyPair},
+		},
+		{
+			name: "not a plan",
+			path: "testdata/invalid.json",
+			err:  "tfplan://testdata/invalid.json: given file is not a Terraform JSON plan, use terraform show -json to convert it",
+		},
+		{
+			name: "missing file",
+			path: "testdata/missing.json",
+			err:  "tfplan://testdata/missing.json: open testdata/missing.json: no such file or directory",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			progress := &output.MockProgress{}
+			progress.On("Inc").Return().Times(1)
+
+			testFilter := &filter.MockFilter{}
+			testFilter.On("IsTypeIgnored", mock.Anything).Return(func(ty resource.ResourceType) bool {
+				return ty.String() == tt.ignoredType
+			})
+
+			factory := terraform.NewTerraformResourceFactory(resource.NewSchemaRepository())
+			r, err := NewReader(config.SupplierConfig{Key: "tfplan", Path: tt.path}, nil, progress, resource.NewDeserializer(factory), testFilter)
+			assert.NoError(t, err)
+
+			got, err := r.Resources()
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			progress.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTerraformPlanReader_UnsupportedBackend(t *testing.T) {
+	_, err := NewReader(config.SupplierConfig{Key: "tfplan", Backend: "tfcloud", Path: "workspace"}, nil, nil, nil, nil)
+	assert.EqualError(t, err, "Unsupported backend 'tfcloud' for Terraform plans")
+}
@@ -0,0 +1,75 @@
+package plan
+
+// Plan is the subset of the Terraform JSON plan representation, as output by
+// terraform show -json, that driftctl reads and writes
+type Plan struct {
+	FormatVersion   string           `json:"format_version,omitempty"`
+	PlannedValues   Values           `json:"planned_values,omitempty"`
+	ResourceChanges []ResourceChange `json:"resource_changes,omitempty"`
+	PriorState      *State           `json:"prior_state,omitempty"`
+}
+
+type State struct {
+	Values Values `json:"values,omitempty"`
+}
+
+type Values struct {
+	RootModule Module `json:"root_module,omitempty"`
+}
+
+type Module struct {
+	Resources    []Resource `json:"resources,omitempty"`
+	Address      string     `json:"address,omitempty"`
+	ChildModules []Module   `json:"child_modules,omitempty"`
+}
+
+type Resource struct {
+	Address         string                 `json:"address,omitempty"`
+	Type            string                 `json:"type,omitempty"`
+	Name            string                 `json:"name,omitempty"`
+	AttributeValues map[string]interface{} `json:"values,omitempty"`
+	Mode            string                 `json:"mode,omitempty"`
+}
+
+type ResourceChange struct {
+	Address       string `json:"address,omitempty"`
+	Type          string `json:"type,omitempty"`
+	Name          string `json:"name,omitempty"`
+	Change        Change `json:"change,omitempty"`
+	Mode          string `json:"mode,omitempty"`
+	ModuleAddress string `json:"module_address,omitempty"`
+}
+
+type Change struct {
+	Actions []string               `json:"actions,omitempty"`
+	Before  map[string]interface{} `json:"before,omitempty"`
+	After   map[string]interface{} `json:"after,omitempty"`
+}
+
+const (
+	ActionNoop   = "no-op"
+	ActionCreate = "create"
+	ActionRead   = "read"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+)
+
+const ModeManaged = "managed"
+
+// resources returns resources of a module and of its child modules
+func (m Module) resources() []Resource {
+	res := append([]Resource{}, m.Resources...)
+	for _, child := range m.ChildModules {
+		res = append(res, child.resources()...)
+	}
+	return res
+}
+
+func (c Change) hasAction(action string) bool {
+	for _, a := range c.Actions {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
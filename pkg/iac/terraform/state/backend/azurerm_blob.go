@@ -0,0 +1,265 @@
+package backend
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/pkg/errors"
+)
+
+const azureBlobAPIVersion = "2020-10-02"
+const azureStorageScope = "https://storage.azure.com/.default"
+
+type AzureBlob struct {
+	Name string
+	Size int64
+}
+
+type AzureBlobListPager interface {
+	Err() error
+	NextPage(ctx context.Context) bool
+	PageResponse() []AzureBlob
+}
+
+// AzureBlobClient is a minimal client of the Azure Blob storage REST API,
+// only used to list and download Terraform states
+type AzureBlobClient interface {
+	Download(account, container, key string) (io.ReadCloser, error)
+	List(account, container, prefix string) AzureBlobListPager
+}
+
+// azureBlobClient authenticates with, in order of precedence, the
+// AZURE_STORAGE_SAS_TOKEN, the AZURE_STORAGE_KEY shared key or Azure AD
+// credentials. AZURE_STORAGE_BLOB_ENDPOINT overrides the blob service
+// endpoint, e.g. to target Azurite.
+type azureBlobClient struct {
+	client     *http.Client
+	endpoint   string
+	sasToken   string
+	accountKey []byte
+	token      func(ctx context.Context) (string, error)
+}
+
+func NewAzureBlobClient() (AzureBlobClient, error) {
+	c := &azureBlobClient{
+		client:   &http.Client{},
+		endpoint: os.Getenv("AZURE_STORAGE_BLOB_ENDPOINT"),
+		sasToken: strings.TrimPrefix(os.Getenv("AZURE_STORAGE_SAS_TOKEN"), "?"),
+	}
+
+	if key := os.Getenv("AZURE_STORAGE_KEY"); c.sasToken == "" && key != "" {
+		decoded, err := base64.StdEncoding.DecodeString(key)
+		if err != nil {
+			return nil, errors.Wrap(err, "Unable to decode Azure storage key")
+		}
+		c.accountKey = decoded
+	}
+
+	if c.sasToken == "" && c.accountKey == nil {
+		cred, err := azidentity.NewDefaultAzureCredential(&azidentity.DefaultAzureCredentialOptions{})
+		if err != nil {
+			return nil, err
+		}
+		c.token = func(ctx context.Context) (string, error) {
+			token, err := cred.GetToken(ctx, policy.TokenRequestOptions{Scopes: []string{azureStorageScope}})
+			if err != nil {
+				return "", err
+			}
+			return token.Token, nil
+		}
+	}
+
+	return c, nil
+}
+
+func (c *azureBlobClient) url(account, container, key string, query url.Values) (*url.URL, error) {
+	endpoint := c.endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://%s.blob.core.windows.net", account)
+	}
+	u, err := url.Parse(strings.TrimSuffix(endpoint, "/"))
+	if err != nil {
+		return nil, err
+	}
+	u.Path = strings.Join([]string{u.Path, container, key}, "/")
+	u.Path = strings.TrimSuffix(u.Path, "/")
+	u.RawQuery = query.Encode()
+	if c.sasToken != "" {
+		if u.RawQuery != "" {
+			u.RawQuery += "&"
+		}
+		u.RawQuery += c.sasToken
+	}
+	return u, nil
+}
+
+func (c *azureBlobClient) do(ctx context.Context, account string, u *url.URL) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("x-ms-version", azureBlobAPIVersion)
+	req.Header.Set("x-ms-date", time.Now().UTC().Format(http.TimeFormat))
+
+	switch {
+	case c.accountKey != nil:
+		req.Header.Set("Authorization", fmt.Sprintf("SharedKey %s:%s", account, c.sign(account, req)))
+	case c.token != nil:
+		token, err := c.token(ctx)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		_ = res.Body.Close()
+		return nil, errors.Errorf("%s %s", res.Status, res.Header.Get("x-ms-error-code"))
+	}
+	return res, nil
+}
+
+// sign computes the shared key signature of a GET request without body
+// https://docs.microsoft.com/en-us/rest/api/storageservices/authorize-with-shared-key
+func (c *azureBlobClient) sign(account string, req *http.Request) string {
+	headers := make([]string, 0)
+	for name := range req.Header {
+		if name := strings.ToLower(name); strings.HasPrefix(name, "x-ms-") {
+			headers = append(headers, name)
+		}
+	}
+	sort.Strings(headers)
+
+	var b strings.Builder
+	// Verb and the 11 standard headers, all empty for a GET request
+	b.WriteString(req.Method)
+	b.WriteString(strings.Repeat("\n", 12))
+	for _, name := range headers {
+		b.WriteString(fmt.Sprintf("%s:%s\n", name, req.Header.Get(name)))
+	}
+	b.WriteString(fmt.Sprintf("/%s%s", account, req.URL.EscapedPath()))
+	query := req.URL.Query()
+	params := make([]string, 0, len(query))
+	for name := range query {
+		params = append(params, name)
+	}
+	sort.Strings(params)
+	for _, name := range params {
+		values := query[name]
+		sort.Strings(values)
+		b.WriteString(fmt.Sprintf("\n%s:%s", strings.ToLower(name), strings.Join(values, ",")))
+	}
+
+	mac := hmac.New(sha256.New, c.accountKey)
+	mac.Write([]byte(b.String()))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (c *azureBlobClient) Download(account, container, key string) (io.ReadCloser, error) {
+	u, err := c.url(account, container, key, url.Values{})
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.do(context.Background(), account, u)
+	if err != nil {
+		return nil, err
+	}
+	return res.Body, nil
+}
+
+func (c *azureBlobClient) List(account, container, prefix string) AzureBlobListPager {
+	return &azureBlobListPager{
+		client:    c,
+		account:   account,
+		container: container,
+		prefix:    prefix,
+	}
+}
+
+type azureBlobListResult struct {
+	Blobs []struct {
+		Name       string `xml:"Name"`
+		Properties struct {
+			ContentLength int64 `xml:"Content-Length"`
+		} `xml:"Properties"`
+	} `xml:"Blobs>Blob"`
+	NextMarker string `xml:"NextMarker"`
+}
+
+type azureBlobListPager struct {
+	client    *azureBlobClient
+	account   string
+	container string
+	prefix    string
+	marker    string
+	done      bool
+	page      []AzureBlob
+	err       error
+}
+
+func (p *azureBlobListPager) Err() error {
+	return p.err
+}
+
+func (p *azureBlobListPager) PageResponse() []AzureBlob {
+	return p.page
+}
+
+func (p *azureBlobListPager) NextPage(ctx context.Context) bool {
+	if p.done || p.err != nil {
+		return false
+	}
+
+	query := url.Values{
+		"restype": []string{"container"},
+		"comp":    []string{"list"},
+	}
+	if p.prefix != "" {
+		query.Set("prefix", p.prefix)
+	}
+	if p.marker != "" {
+		query.Set("marker", p.marker)
+	}
+	u, err := p.client.url(p.account, p.container, "", query)
+	if err != nil {
+		p.err = err
+		return false
+	}
+	res, err := p.client.do(ctx, p.account, u)
+	if err != nil {
+		p.err = err
+		return false
+	}
+	defer res.Body.Close()
+
+	result := azureBlobListResult{}
+	if err := xml.NewDecoder(res.Body).Decode(&result); err != nil {
+		p.err = err
+		return false
+	}
+
+	p.page = make([]AzureBlob, 0, len(result.Blobs))
+	for _, blob := range result.Blobs {
+		p.page = append(p.page, AzureBlob{Name: blob.Name, Size: blob.Properties.ContentLength})
+	}
+	p.marker = result.NextMarker
+	p.done = p.marker == ""
+	return true
+}
@@ -0,0 +1,77 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAzureBlobClient_Sign(t *testing.T) {
+	c := &azureBlobClient{accountKey: []byte("secret-key")}
+	req, _ := http.NewRequest(http.MethodGet, "https://account.blob.core.windows.net/container?restype=container&comp=list&prefix=env%2Fprod", nil)
+	req.Header.Set("x-ms-version", azureBlobAPIVersion)
+	req.Header.Set("x-ms-date", "Mon, 18 Oct 2021 12:00:00 GMT")
+
+	assert.Equal(t, "Ir7prqORXMtUIgr5cPcAs86ZGlFgVVwIcXqKqiajS6Q=", c.sign("account", req))
+}
+
+func TestAzureBlobClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("sig") != "signature" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/container":
+			assert.Equal(t, "list", r.URL.Query().Get("comp"))
+			assert.Equal(t, "states/", r.URL.Query().Get("prefix"))
+			if r.URL.Query().Get("marker") == "" {
+				fmt.Fprint(w, `<?xml version="1.0" encoding="utf-8"?><EnumerationResults><Blobs><Blob><Name>states/a.tfstate</Name><Properties><Content-Length>2</Content-Length></Properties></Blob></Blobs><NextMarker>next</NextMarker></EnumerationResults>`)
+				return
+			}
+			fmt.Fprint(w, `<?xml version="1.0" encoding="utf-8"?><EnumerationResults><Blobs><Blob><Name>states/b.tfstate</Name><Properties><Content-Length>0</Content-Length></Properties></Blob></Blobs><NextMarker /></EnumerationResults>`)
+		case "/container/states/a.tfstate":
+			fmt.Fprint(w, "{}")
+		default:
+			w.Header().Set("x-ms-error-code", "BlobNotFound")
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	os.Setenv("AZURE_STORAGE_BLOB_ENDPOINT", server.URL)
+	os.Setenv("AZURE_STORAGE_SAS_TOKEN", "?sv=2020-10-02&sig=signature")
+	defer os.Unsetenv("AZURE_STORAGE_BLOB_ENDPOINT")
+	defer os.Unsetenv("AZURE_STORAGE_SAS_TOKEN")
+
+	client, err := NewAzureBlobClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pager := client.List("account", "container", "states/")
+	var blobs []AzureBlob
+	for pager.NextPage(context.Background()) {
+		blobs = append(blobs, pager.PageResponse()...)
+	}
+	assert.Nil(t, pager.Err())
+	assert.Equal(t, []AzureBlob{
+		{Name: "states/a.tfstate", Size: 2},
+		{Name: "states/b.tfstate", Size: 0},
+	}, blobs)
+
+	reader, err := client.Download("account", "container", "states/a.tfstate")
+	assert.Nil(t, err)
+	content, _ := ioutil.ReadAll(reader)
+	assert.Equal(t, "{}", string(content))
+	assert.Nil(t, reader.Close())
+
+	_, err = client.Download("account", "container", "states/missing.tfstate")
+	assert.EqualError(t, err, "404 Not Found BlobNotFound")
+}
@@ -0,0 +1,65 @@
+package backend
+
+import (
+	"io"
+	"strings"
+
+	"github.com/cloudskiff/driftctl/pkg/envproxy"
+	"github.com/pkg/errors"
+)
+
+const BackendKeyAzureRM = "azurerm"
+
+type AzureRMBackend struct {
+	account   string
+	container string
+	key       string
+	reader    io.ReadCloser
+	client    AzureBlobClient
+}
+
+func NewAzureRMReader(path string) (*AzureRMBackend, error) {
+	accountContainerKey := strings.SplitN(path, "/", 3)
+	if len(accountContainerKey) < 3 || accountContainerKey[2] == "" {
+		return nil, errors.Errorf("Unable to parse azurerm path: %s. Must be STORAGE_ACCOUNT/CONTAINER/PATH/TO/BLOB", path)
+	}
+
+	envProxy := envproxy.NewEnvProxy("DCTL_AZURERM_", "AZURE_")
+	envProxy.Apply()
+	client, err := NewAzureBlobClient()
+	envProxy.Restore()
+	if err != nil {
+		return nil, err
+	}
+
+	return &AzureRMBackend{
+		account:   accountContainerKey[0],
+		container: accountContainerKey[1],
+		key:       accountContainerKey[2],
+		client:    client,
+	}, nil
+}
+
+func (s *AzureRMBackend) Read(p []byte) (n int, err error) {
+	if s.reader == nil {
+		reader, err := s.client.Download(s.account, s.container, s.key)
+		if err != nil {
+			return 0, errors.Errorf(
+				"Error reading state '%s' from azurerm container '%s' of storage account '%s': %s",
+				s.key,
+				s.container,
+				s.account,
+				err,
+			)
+		}
+		s.reader = reader
+	}
+	return s.reader.Read(p)
+}
+
+func (s *AzureRMBackend) Close() error {
+	if s.reader != nil {
+		return s.reader.Close()
+	}
+	return errors.New("Unable to close reader as nothing was opened")
+}
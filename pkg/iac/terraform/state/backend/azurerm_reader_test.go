@@ -0,0 +1,64 @@
+package backend
+
+import (
+	"errors"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAzureRMReaderInvalid(t *testing.T) {
+	for _, path := range []string{"account", "account/container", "account/container/"} {
+		got, err := NewAzureRMReader(path)
+		assert.Nil(t, got)
+		assert.EqualError(t, err, "Unable to parse azurerm path: "+path+". Must be STORAGE_ACCOUNT/CONTAINER/PATH/TO/BLOB")
+	}
+}
+
+func TestAzureRMBackend_Read(t *testing.T) {
+	tests := []struct {
+		name    string
+		mock    func(client *MockAzureBlobClient)
+		want    string
+		wantErr string
+	}{
+		{
+			name: "read existing state",
+			mock: func(client *MockAzureBlobClient) {
+				client.On("Download", "account", "container", "path/to/state.tfstate").
+					Return(ioutil.NopCloser(strings.NewReader("{}")), nil)
+			},
+			want: "{}",
+		},
+		{
+			name: "read missing state",
+			mock: func(client *MockAzureBlobClient) {
+				client.On("Download", "account", "container", "path/to/state.tfstate").
+					Return(nil, errors.New("404 Not Found BlobNotFound"))
+			},
+			wantErr: "Error reading state 'path/to/state.tfstate' from azurerm container 'container' of storage account 'account': 404 Not Found BlobNotFound",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &MockAzureBlobClient{}
+			tt.mock(client)
+			reader := &AzureRMBackend{
+				account:   "account",
+				container: "container",
+				key:       "path/to/state.tfstate",
+				client:    client,
+			}
+			got, err := ioutil.ReadAll(reader)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.want, string(got))
+			}
+			client.AssertExpectations(t)
+		})
+	}
+}
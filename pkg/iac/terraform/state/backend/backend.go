@@ -12,9 +12,12 @@ import (
 var supportedBackends = []string{
 	BackendKeyFile,
 	BackendKeyS3,
+	BackendKeyGS,
+	BackendKeyAzureRM,
 	BackendKeyHTTP,
 	BackendKeyHTTPS,
 	BackendKeyTFCloud,
+	BackendKeyWorkdir,
 }
 
 type Backend io.ReadCloser
@@ -23,6 +26,8 @@ type Options struct {
 	Headers         map[string]string
 	TFCloudToken    string
 	TFCloudEndpoint string
+	// StateCache is used by backends supporting conditional reads when set
+	StateCache *StateCache
 }
 
 func IsSupported(backend string) bool {
@@ -46,13 +51,30 @@ func GetBackend(config config.SupplierConfig, opts *Options) (Backend, error) {
 	case BackendKeyFile:
 		return NewFileReader(config.Path)
 	case BackendKeyS3:
-		return NewS3Reader(config.Path)
+		reader, err := NewS3Reader(config.Path)
+		if err != nil {
+			return nil, err
+		}
+		if opts != nil {
+			reader.cache = opts.StateCache
+		}
+		return reader, nil
+	case BackendKeyGS:
+		return NewGSReader(config.Path)
+	case BackendKeyAzureRM:
+		return NewAzureRMReader(config.Path)
 	case BackendKeyHTTP:
 		fallthrough
 	case BackendKeyHTTPS:
 		return NewHTTPReader(&http.Client{}, fmt.Sprintf("%s://%s", config.Backend, config.Path), opts)
 	case BackendKeyTFCloud:
 		return NewTFCloudReader(&http.Client{}, config.Path, opts)
+	case BackendKeyWorkdir:
+		resolved, err := ResolveWorkdir(config.Path)
+		if err != nil {
+			return nil, err
+		}
+		return GetBackend(*resolved, opts)
 	default:
 		return nil, errors.Errorf("Unsupported backend '%s'", backend)
 	}
@@ -0,0 +1,65 @@
+package backend
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"cloud.google.com/go/storage"
+	"github.com/cloudskiff/driftctl/pkg/envproxy"
+	"github.com/pkg/errors"
+	"google.golang.org/api/option"
+)
+
+const BackendKeyGS = "gs"
+
+type GSBackend struct {
+	bucket   string
+	key      string
+	reader   io.ReadCloser
+	GSClient *storage.Client
+}
+
+func NewGSReader(path string) (*GSBackend, error) {
+	bucketPath := strings.Split(path, "/")
+	if len(bucketPath) < 2 {
+		return nil, errors.Errorf("Unable to parse GCS path: %s. Must be BUCKET_NAME/PATH/TO/OBJECT", path)
+	}
+
+	backend := GSBackend{
+		bucket: bucketPath[0],
+		key:    strings.Join(bucketPath[1:], "/"),
+	}
+	envProxy := envproxy.NewEnvProxy("DCTL_GS_", "GOOGLE_")
+	envProxy.Apply()
+	client, err := storage.NewClient(context.Background(), option.WithScopes(storage.ScopeReadOnly))
+	envProxy.Restore()
+	if err != nil {
+		return nil, err
+	}
+	backend.GSClient = client
+	return &backend, nil
+}
+
+func (s *GSBackend) Read(p []byte) (n int, err error) {
+	if s.reader == nil {
+		reader, err := s.GSClient.Bucket(s.bucket).Object(s.key).NewReader(context.Background())
+		if err != nil {
+			return 0, errors.Errorf(
+				"Error reading state '%s' from gs bucket '%s': %s",
+				s.key,
+				s.bucket,
+				err,
+			)
+		}
+		s.reader = reader
+	}
+	return s.reader.Read(p)
+}
+
+func (s *GSBackend) Close() error {
+	if s.reader != nil {
+		return s.reader.Close()
+	}
+	return errors.New("Unable to close reader as nothing was opened")
+}
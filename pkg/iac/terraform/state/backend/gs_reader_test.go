@@ -0,0 +1,68 @@
+package backend
+
+import (
+	"io/ioutil"
+	"testing"
+
+	googletest "github.com/cloudskiff/driftctl/test/google"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewGSReaderInvalid(t *testing.T) {
+	got, err := NewGSReader("foobar")
+	assert.Nil(t, got)
+	assert.EqualError(t, err, "Unable to parse GCS path: foobar. Must be BUCKET_NAME/PATH/TO/OBJECT")
+}
+
+func TestGSBackend_Read(t *testing.T) {
+	state, err := ioutil.ReadFile("testdata/valid.tfstate")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := googletest.NewFakeStorageServer(t, map[string]string{
+		"sample_bucket/path/to/state.tfstate": string(state),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		backend *GSBackend
+		want    []byte
+		wantErr string
+	}{
+		{
+			name: "read existing state",
+			backend: &GSBackend{
+				bucket:   "sample_bucket",
+				key:      "path/to/state.tfstate",
+				GSClient: client,
+			},
+			want: state,
+		},
+		{
+			name: "read missing state",
+			backend: &GSBackend{
+				bucket:   "sample_bucket",
+				key:      "path/to/missing.tfstate",
+				GSClient: client,
+			},
+			wantErr: "Error reading state 'path/to/missing.tfstate' from gs bucket 'sample_bucket': storage: object doesn't exist",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ioutil.ReadAll(tt.backend)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.EqualError(t, tt.backend.Close(), "Unable to close reader as nothing was opened")
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Nil(t, tt.backend.Close())
+		})
+	}
+}
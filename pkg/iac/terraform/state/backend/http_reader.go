@@ -5,8 +5,12 @@ import (
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 
+	"bytes"
 	"io"
+	"io/ioutil"
 	"net/http"
+	"net/url"
+	"strings"
 )
 
 const BackendKeyHTTP = "http"
@@ -16,6 +20,7 @@ type HTTPBackend struct {
 	request *http.Request
 	client  pkghttp.HTTPClient
 	reader  io.ReadCloser
+	cache   *StateCache
 }
 
 func NewHTTPReader(client pkghttp.HTTPClient, rawURL string, opts *Options) (*HTTPBackend, error) {
@@ -28,25 +33,84 @@ func NewHTTPReader(client pkghttp.HTTPClient, rawURL string, opts *Options) (*HT
 		req.Header.Add(key, value)
 	}
 
-	return &HTTPBackend{req, client, nil}, nil
+	return &HTTPBackend{req, client, nil, opts.StateCache}, nil
 }
 
 func (h *HTTPBackend) Read(p []byte) (n int, err error) {
 	if h.reader == nil {
-		res, err := h.client.Do(h.request)
+		reader, err := h.open()
 		if err != nil {
 			return 0, err
 		}
-		h.reader = res.Body
-
-		if res.StatusCode < 200 || res.StatusCode >= 400 {
-			body, _ := io.ReadAll(h.reader)
-			logrus.WithFields(logrus.Fields{"body": string(body)}).Trace("HTTP(s) backend response")
+		h.reader = reader
+	}
+	return h.reader.Read(p)
+}
 
-			return 0, errors.Errorf("error requesting HTTP(s) backend state: status code: %d", res.StatusCode)
+// open downloads the state with a conditional request when it is cached, the
+// cached version is either an ETag or a Last-Modified date
+func (h *HTTPBackend) open() (io.ReadCloser, error) {
+	cacheKey := h.request.URL.String()
+	var cached []byte
+	if h.cache != nil {
+		if version, content, hit := h.cache.Get(cacheKey); hit {
+			cached = content
+			if isETag(version) {
+				h.request.Header.Set("If-None-Match", version)
+			} else {
+				h.request.Header.Set("If-Modified-Since", version)
+			}
 		}
 	}
-	return h.reader.Read(p)
+
+	res, err := h.client.Do(h.request)
+	if err != nil {
+		return nil, err
+	}
+
+	if cached != nil && res.StatusCode == http.StatusNotModified {
+		res.Body.Close()
+		logrus.WithFields(logrus.Fields{"url": redactURL(h.request.URL)}).Debug("Read state from cache")
+		return ioutil.NopCloser(bytes.NewReader(cached)), nil
+	}
+
+	if res.StatusCode < 200 || res.StatusCode >= 400 {
+		body, _ := io.ReadAll(res.Body)
+		res.Body.Close()
+		logrus.WithFields(logrus.Fields{"body": string(body)}).Trace("HTTP(s) backend response")
+
+		return nil, errors.Errorf("error requesting HTTP(s) backend state: status code: %d", res.StatusCode)
+	}
+
+	version := res.Header.Get("ETag")
+	if version == "" {
+		version = res.Header.Get("Last-Modified")
+	}
+	if h.cache == nil || version == "" {
+		return res.Body, nil
+	}
+
+	defer res.Body.Close()
+	content, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.cache.Put(cacheKey, version, content); err != nil {
+		logrus.WithFields(logrus.Fields{"url": redactURL(h.request.URL), "error": err}).Warn("Unable to cache state")
+	}
+	return ioutil.NopCloser(bytes.NewReader(content)), nil
+}
+
+// redactURL strips credentials, either basic auth ones or query tokens
+func redactURL(u *url.URL) string {
+	redacted := *u
+	redacted.User = nil
+	redacted.RawQuery = ""
+	return redacted.String()
+}
+
+func isETag(version string) bool {
+	return strings.HasPrefix(version, "\"") || strings.HasPrefix(version, "W/")
 }
 
 func (h *HTTPBackend) Close() error {
@@ -1,12 +1,19 @@
 package backend
 
 import (
+	"bytes"
+	"fmt"
 	"io"
+	"io/ioutil"
+	"net/http"
 	"strings"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/cloudskiff/driftctl/pkg/envproxy"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3iface"
@@ -18,6 +25,7 @@ type S3Backend struct {
 	input    s3.GetObjectInput
 	reader   io.ReadCloser
 	S3Client s3iface.S3API
+	cache    *StateCache
 }
 
 func NewS3Reader(path string) (*S3Backend, error) {
@@ -46,24 +54,60 @@ func NewS3Reader(path string) (*S3Backend, error) {
 
 func (s *S3Backend) Read(p []byte) (n int, err error) {
 	if s.reader == nil {
-		response, err := s.S3Client.GetObject(&s.input)
+		reader, err := s.open()
 		if err != nil {
-			requestFailure, ok := err.(s3.RequestFailure)
-			if ok {
-				return 0, errors.Errorf(
-					"Error reading state '%s' from s3 bucket '%s': %s",
-					*s.input.Key,
-					*s.input.Bucket,
-					requestFailure.Message(),
-				)
-			}
 			return 0, err
 		}
-		s.reader = response.Body
+		s.reader = reader
 	}
 	return s.reader.Read(p)
 }
 
+// open downloads the state, or reads it from the cache when its ETag did not
+// change since it was cached
+func (s *S3Backend) open() (io.ReadCloser, error) {
+	input := s.input
+	cacheKey := fmt.Sprintf("s3://%s/%s", *input.Bucket, *input.Key)
+	var cached []byte
+	if s.cache != nil {
+		if etag, content, hit := s.cache.Get(cacheKey); hit {
+			input.IfNoneMatch = aws.String(etag)
+			cached = content
+		}
+	}
+
+	response, err := s.S3Client.GetObject(&input)
+	if err != nil {
+		if failure, ok := err.(awserr.RequestFailure); ok && cached != nil && failure.StatusCode() == http.StatusNotModified {
+			logrus.WithFields(logrus.Fields{"key": cacheKey}).Debug("Read state from cache")
+			return ioutil.NopCloser(bytes.NewReader(cached)), nil
+		}
+		requestFailure, ok := err.(s3.RequestFailure)
+		if ok {
+			return nil, errors.Errorf(
+				"Error reading state '%s' from s3 bucket '%s': %s",
+				*input.Key,
+				*input.Bucket,
+				requestFailure.Message(),
+			)
+		}
+		return nil, err
+	}
+
+	if s.cache == nil {
+		return response.Body, nil
+	}
+	defer response.Body.Close()
+	content, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.cache.Put(cacheKey, aws.StringValue(response.ETag), content); err != nil {
+		logrus.WithFields(logrus.Fields{"key": cacheKey, "error": err}).Warn("Unable to cache state")
+	}
+	return ioutil.NopCloser(bytes.NewReader(content)), nil
+}
+
 func (s *S3Backend) Close() error {
 	if s.reader != nil {
 		return s.reader.Close()
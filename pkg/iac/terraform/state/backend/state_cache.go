@@ -0,0 +1,153 @@
+package backend
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// StateCacheDefaultMaxSize is the default size limit of the state cache, in bytes
+const StateCacheDefaultMaxSize = 512 * 1024 * 1024
+
+// StateCache keeps downloaded states on disk along with the version they were
+// downloaded at, e.g. an S3 ETag or a Terraform Cloud state version id, so
+// backends can skip downloading states that did not change.
+// Least recently used states are evicted once the size limit is reached.
+// Entries are named after a hash of their key, keys like URLs may hold
+// credentials so they are never written to disk.
+type StateCache struct {
+	dir     string
+	maxSize int64
+	mu      sync.Mutex
+}
+
+type stateCacheEntry struct {
+	Version string `json:"version"`
+}
+
+func NewStateCache(configDir string, maxSize int64) *StateCache {
+	return &StateCache{
+		dir:     path.Join(configDir, ".driftctl", "state-cache"),
+		maxSize: maxSize,
+	}
+}
+
+func (c *StateCache) Dir() string {
+	return c.dir
+}
+
+// entryName hashes the state key, keys may contain credentials so they are
+// never written to disk nor logged
+func entryName(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
+
+func (c *StateCache) entryPath(key string) string {
+	return path.Join(c.dir, entryName(key))
+}
+
+// Get returns the cached version and content of a state, if any
+func (c *StateCache) Get(key string) (string, []byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entryPath := c.entryPath(key)
+	rawEntry, err := os.ReadFile(entryPath + ".json")
+	if err != nil {
+		return "", nil, false
+	}
+	entry := stateCacheEntry{}
+	if err := json.Unmarshal(rawEntry, &entry); err != nil {
+		return "", nil, false
+	}
+	content, err := os.ReadFile(entryPath + ".tfstate")
+	if err != nil {
+		return "", nil, false
+	}
+
+	// Keep track of the last use to evict least recently used states first
+	now := time.Now()
+	_ = os.Chtimes(entryPath+".tfstate", now, now)
+
+	return entry.Version, content, true
+}
+
+// Put stores a state, states bigger than the cache size limit are not stored
+func (c *StateCache) Put(key, version string, content []byte) error {
+	if int64(len(content)) > c.maxSize {
+		logrus.WithFields(logrus.Fields{
+			"entry": entryName(key),
+			"size":  len(content),
+		}).Debug("State is too big to be cached")
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := os.MkdirAll(c.dir, 0700); err != nil {
+		return err
+	}
+
+	rawEntry, err := json.Marshal(stateCacheEntry{Version: version})
+	if err != nil {
+		return err
+	}
+	entryPath := c.entryPath(key)
+	if err := os.WriteFile(entryPath+".tfstate", content, 0600); err != nil {
+		return err
+	}
+	if err := os.WriteFile(entryPath+".json", rawEntry, 0600); err != nil {
+		return err
+	}
+
+	return c.evict()
+}
+
+func (c *StateCache) evict() error {
+	files, err := filepath.Glob(path.Join(c.dir, "*.tfstate"))
+	if err != nil {
+		return err
+	}
+
+	infos := make([]os.FileInfo, 0, len(files))
+	var size int64
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			continue
+		}
+		infos = append(infos, info)
+		size += info.Size()
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].ModTime().Before(infos[j].ModTime())
+	})
+
+	for _, info := range infos {
+		if size <= c.maxSize {
+			break
+		}
+		entryPath := path.Join(c.dir, info.Name())
+		if err := os.Remove(entryPath); err != nil {
+			return err
+		}
+		_ = os.Remove(strings.TrimSuffix(entryPath, ".tfstate") + ".json")
+		size -= info.Size()
+		logrus.WithFields(logrus.Fields{
+			"file": info.Name(),
+		}).Debug("Evicted state from cache")
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package backend
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -20,6 +21,7 @@ type TFCloudAttributes struct {
 }
 
 type TFCloudData struct {
+	Id         string            `json:"id"`
 	Attributes TFCloudAttributes `json:"attributes"`
 }
 
@@ -44,27 +46,33 @@ func NewTFCloudReader(client pkghttp.HTTPClient, workspaceId string, opts *Optio
 }
 
 func (t *TFCloudBackend) authorize() error {
-	token := t.opts.TFCloudToken
-	if token == "" {
-		tfConfigFile, err := getTerraformConfigFile()
-		if err != nil {
-			return err
-		}
-		file, err := os.Open(tfConfigFile)
-		if err != nil {
-			return err
-		}
-		defer file.Close()
-		reader := NewTFCloudConfigReader(file)
-		token, err = reader.GetToken(t.request.URL.Host)
-		if err != nil {
-			return err
-		}
+	token, err := GetTFCloudToken(t.opts, t.request.URL.Host)
+	if err != nil {
+		return err
 	}
 	t.request.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token))
 	return nil
 }
 
+// GetTFCloudToken returns the token given in options or falls back to the one
+// stored for the host in the Terraform credentials file
+func GetTFCloudToken(opts *Options, host string) (string, error) {
+	if opts.TFCloudToken != "" {
+		return opts.TFCloudToken, nil
+	}
+	tfConfigFile, err := getTerraformConfigFile()
+	if err != nil {
+		return "", err
+	}
+	file, err := os.Open(tfConfigFile)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	reader := NewTFCloudConfigReader(file)
+	return reader.GetToken(host)
+}
+
 func (t *TFCloudBackend) Read(p []byte) (n int, err error) {
 	if t.reader == nil {
 		if err := t.authorize(); err != nil {
@@ -86,18 +94,47 @@ func (t *TFCloudBackend) Read(p []byte) (n int, err error) {
 			return 0, err
 		}
 
-		rawURL := body.Data.Attributes.HostedStateDownloadUrl
-		logrus.WithFields(logrus.Fields{"hosted-state-download-url": rawURL}).Trace("Terraform Cloud backend response")
-
-		h, err := NewHTTPReader(t.client, rawURL, &Options{})
+		reader, err := t.download(body.Data)
 		if err != nil {
 			return 0, err
 		}
-		t.reader = h
+		t.reader = reader
 	}
 	return t.reader.Read(p)
 }
 
+// download reads the current state version, unless it is already cached
+func (t *TFCloudBackend) download(stateVersion TFCloudData) (io.ReadCloser, error) {
+	cacheKey := t.request.URL.String()
+	if t.opts.StateCache != nil {
+		if version, content, hit := t.opts.StateCache.Get(cacheKey); hit && version == stateVersion.Id {
+			logrus.WithFields(logrus.Fields{"state-version": version}).Debug("Read state from cache")
+			return ioutil.NopCloser(bytes.NewReader(content)), nil
+		}
+	}
+
+	rawURL := stateVersion.Attributes.HostedStateDownloadUrl
+	logrus.WithFields(logrus.Fields{"hosted-state-download-url": rawURL}).Trace("Terraform Cloud backend response")
+
+	h, err := NewHTTPReader(t.client, rawURL, &Options{})
+	if err != nil {
+		return nil, err
+	}
+	if t.opts.StateCache == nil || stateVersion.Id == "" {
+		return h, nil
+	}
+
+	defer h.Close()
+	content, err := ioutil.ReadAll(h)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.opts.StateCache.Put(cacheKey, stateVersion.Id, content); err != nil {
+		logrus.WithFields(logrus.Fields{"state-version": stateVersion.Id, "error": err}).Warn("Unable to cache state")
+	}
+	return ioutil.NopCloser(bytes.NewReader(content)), nil
+}
+
 func (t *TFCloudBackend) Close() error {
 	if t.reader != nil {
 		return t.reader.Close()
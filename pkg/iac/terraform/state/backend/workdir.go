@@ -0,0 +1,277 @@
+package backend
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+)
+
+// BackendKeyWorkdir is not a backend by itself, a Terraform working directory
+// is resolved to the backend configured in it
+const BackendKeyWorkdir = "workdir"
+
+const defaultWorkspace = "default"
+
+// workdirBackend is the backend configuration of a Terraform working directory,
+// as written in .terraform/terraform.tfstate by terraform init
+type workdirBackend struct {
+	Type   string                 `json:"type"`
+	Config map[string]interface{} `json:"config"`
+}
+
+func (b *workdirBackend) get(key string) string {
+	if v, ok := b.Config[key].(string); ok {
+		return v
+	}
+	return ""
+}
+
+func (b *workdirBackend) getOrDefault(key, defaultValue string) string {
+	if v := b.get(key); v != "" {
+		return v
+	}
+	return defaultValue
+}
+
+// workspaces returns the workspaces block of remote and cloud backends, which
+// is a list of objects in .terraform/terraform.tfstate and an object in HCL
+func (b *workdirBackend) workspaces() map[string]interface{} {
+	switch v := b.Config["workspaces"].(type) {
+	case map[string]interface{}:
+		return v
+	case []interface{}:
+		if len(v) > 0 {
+			if m, ok := v[0].(map[string]interface{}); ok {
+				return m
+			}
+		}
+	}
+	return map[string]interface{}{}
+}
+
+// ResolveWorkdir reads the backend configuration and the selected workspace
+// of a Terraform working directory and returns the matching state source
+func ResolveWorkdir(dir string) (*config.SupplierConfig, error) {
+	b, err := readWorkdirBackend(dir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Unable to read backend configuration of %s", dir)
+	}
+	workspace := currentWorkspace(dir)
+
+	logrus.WithFields(logrus.Fields{
+		"dir":       dir,
+		"backend":   b.Type,
+		"workspace": workspace,
+	}).Debug("Resolving state of Terraform working directory")
+
+	supplierConfig := &config.SupplierConfig{Key: "tfstate"}
+	switch b.Type {
+	case "local":
+		supplierConfig.Backend = BackendKeyFile
+		supplierConfig.Path = b.getOrDefault("path", "terraform.tfstate")
+		if workspace != defaultWorkspace {
+			supplierConfig.Path = filepath.Join(b.getOrDefault("workspace_dir", "terraform.tfstate.d"), workspace, "terraform.tfstate")
+		}
+		if !filepath.IsAbs(supplierConfig.Path) {
+			supplierConfig.Path = filepath.Join(dir, supplierConfig.Path)
+		}
+	case "s3":
+		key := b.get("key")
+		if workspace != defaultWorkspace {
+			key = strings.Join([]string{b.getOrDefault("workspace_key_prefix", "env:"), workspace, key}, "/")
+		}
+		supplierConfig.Backend = BackendKeyS3
+		supplierConfig.Path = strings.Join([]string{b.get("bucket"), key}, "/")
+	case "gcs":
+		supplierConfig.Backend = BackendKeyGS
+		supplierConfig.Path = strings.Trim(strings.Join([]string{b.get("bucket"), b.get("prefix"), workspace + ".tfstate"}, "/"), "/")
+	case "azurerm":
+		key := b.get("key")
+		if workspace != defaultWorkspace {
+			key = fmt.Sprintf("%senv:%s", key, workspace)
+		}
+		supplierConfig.Backend = BackendKeyAzureRM
+		supplierConfig.Path = strings.Join([]string{b.get("storage_account_name"), b.get("container_name"), key}, "/")
+	case "http":
+		address := b.get("address")
+		scheme := strings.SplitN(address, "://", 2)
+		if len(scheme) != 2 {
+			return nil, errors.Errorf("Invalid http backend address '%s' in %s", address, dir)
+		}
+		supplierConfig.Backend = scheme[0]
+		supplierConfig.Path = scheme[1]
+	case "remote", "cloud":
+		if hostname := b.get("hostname"); hostname != "" && hostname != "app.terraform.io" {
+			logrus.WithField("hostname", hostname).Warn("Terraform Enterprise backend detected, make sure --tfc-endpoint targets it")
+		}
+		workspaces := b.workspaces()
+		query := url.Values{}
+		if name, _ := workspaces["name"].(string); name != "" {
+			query.Set("name", name)
+		} else if prefix, _ := workspaces["prefix"].(string); prefix != "" {
+			query.Set("name", prefix+workspace)
+		} else if tags, _ := workspaces["tags"].([]interface{}); len(tags) > 0 {
+			strTags := make([]string, 0, len(tags))
+			for _, tag := range tags {
+				strTags = append(strTags, fmt.Sprint(tag))
+			}
+			query.Set("tags", strings.Join(strTags, ","))
+			// Workspaces selected by tags are named after the selected workspace,
+			// otherwise every tagged workspace is read
+			if workspace != defaultWorkspace {
+				query.Set("name", workspace)
+			}
+		} else {
+			return nil, errors.Errorf("Unable to find the Terraform Cloud workspaces of %s", dir)
+		}
+		supplierConfig.Backend = BackendKeyTFCloud
+		supplierConfig.Path = fmt.Sprintf("org/%s?%s", b.get("organization"), query.Encode())
+	default:
+		return nil, errors.Errorf("Unsupported backend '%s' in %s", b.Type, dir)
+	}
+
+	return supplierConfig, nil
+}
+
+// currentWorkspace mimics terraform workspace show
+func currentWorkspace(dir string) string {
+	if workspace := os.Getenv("TF_WORKSPACE"); workspace != "" {
+		return workspace
+	}
+	content, err := ioutil.ReadFile(filepath.Join(dir, ".terraform", "environment"))
+	if err != nil || strings.TrimSpace(string(content)) == "" {
+		return defaultWorkspace
+	}
+	return strings.TrimSpace(string(content))
+}
+
+// readWorkdirBackend prefers the configuration saved by terraform init, as it
+// includes -backend-config values, over the backend block of the sources
+func readWorkdirBackend(dir string) (*workdirBackend, error) {
+	content, err := ioutil.ReadFile(filepath.Join(dir, ".terraform", "terraform.tfstate"))
+	if err == nil {
+		state := struct {
+			Backend *workdirBackend `json:"backend"`
+		}{}
+		if err := json.Unmarshal(content, &state); err != nil {
+			return nil, err
+		}
+		if state.Backend != nil && state.Backend.Type != "" {
+			return state.Backend, nil
+		}
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.tf"))
+	if err != nil {
+		return nil, err
+	}
+	parser := hclparse.NewParser()
+	for _, file := range files {
+		f, diags := parser.ParseHCLFile(file)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+		b, diags := decodeBackendBlock(f.Body)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+		if b != nil {
+			return b, nil
+		}
+	}
+
+	// Terraform defaults to the local backend
+	return &workdirBackend{Type: "local", Config: map[string]interface{}{}}, nil
+}
+
+var terraformBlockSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{{Type: "terraform"}},
+}
+
+var backendBlockSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "backend", LabelNames: []string{"type"}},
+		{Type: "cloud"},
+	},
+}
+
+func decodeBackendBlock(body hcl.Body) (*workdirBackend, hcl.Diagnostics) {
+	content, _, diags := body.PartialContent(terraformBlockSchema)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	for _, terraformBlock := range content.Blocks {
+		terraformContent, _, diags := terraformBlock.Body.PartialContent(backendBlockSchema)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+		for _, block := range terraformContent.Blocks {
+			b := &workdirBackend{Type: block.Type}
+			if block.Type == "backend" {
+				b.Type = block.Labels[0]
+			}
+			b.Config, diags = decodeBlockAttributes(block.Body)
+			return b, diags
+		}
+	}
+	return nil, nil
+}
+
+// decodeBlockAttributes decodes literal attributes of a block and of its
+// nested workspaces block
+func decodeBlockAttributes(body hcl.Body) (map[string]interface{}, hcl.Diagnostics) {
+	syntaxBody, ok := body.(*hclsyntax.Body)
+	if !ok {
+		return nil, hcl.Diagnostics{{
+			Severity: hcl.DiagError,
+			Summary:  "Unsupported backend configuration syntax",
+		}}
+	}
+
+	values := map[string]interface{}{}
+	for name, attr := range syntaxBody.Attributes {
+		value, diags := attr.Expr.Value(nil)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+		// Only primitive values and lists of them, like workspaces tags, are
+		// used to locate the state
+		if list, err := convert.Convert(value, cty.List(cty.String)); err == nil && list.IsKnown() && !list.IsNull() {
+			items := make([]interface{}, 0, list.LengthInt())
+			for _, item := range list.AsValueSlice() {
+				items = append(items, item.AsString())
+			}
+			values[name] = items
+			continue
+		}
+		str, err := convert.Convert(value, cty.String)
+		if err != nil || str.IsNull() || !str.IsKnown() {
+			continue
+		}
+		values[name] = str.AsString()
+	}
+	for _, block := range syntaxBody.Blocks {
+		if block.Type != "workspaces" {
+			continue
+		}
+		workspaces, diags := decodeBlockAttributes(block.Body)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+		values["workspaces"] = workspaces
+	}
+	return values, nil
+}
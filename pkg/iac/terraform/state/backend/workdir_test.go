@@ -0,0 +1,206 @@
+package backend
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveWorkdir(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		env   map[string]string
+		want  func(dir string) *config.SupplierConfig
+		err   string
+	}{
+		{
+			name: "test local backend by default",
+			files: map[string]string{
+				"main.tf": `resource "aws_s3_bucket" "foo" {}`,
+			},
+			want: func(dir string) *config.SupplierConfig {
+				return &config.SupplierConfig{Key: "tfstate", Backend: BackendKeyFile, Path: filepath.Join(dir, "terraform.tfstate")}
+			},
+		},
+		{
+			name: "test local backend with selected workspace",
+			files: map[string]string{
+				"main.tf": `
+terraform {
+  backend "local" {}
+}`,
+				".terraform/environment": "staging",
+			},
+			want: func(dir string) *config.SupplierConfig {
+				return &config.SupplierConfig{Key: "tfstate", Backend: BackendKeyFile, Path: filepath.Join(dir, "terraform.tfstate.d", "staging", "terraform.tfstate")}
+			},
+		},
+		{
+			name: "test s3 backend from sources",
+			files: map[string]string{
+				"backend.tf": `
+terraform {
+  required_version = ">= 0.14"
+  backend "s3" {
+    bucket  = "my-bucket"
+    key     = "path/to/terraform.tfstate"
+    region  = "eu-west-3"
+    encrypt = true
+  }
+}`,
+			},
+			want: func(dir string) *config.SupplierConfig {
+				return &config.SupplierConfig{Key: "tfstate", Backend: BackendKeyS3, Path: "my-bucket/path/to/terraform.tfstate"}
+			},
+		},
+		{
+			name: "test s3 backend initialized with workspace from env",
+			files: map[string]string{
+				"backend.tf": `
+terraform {
+  backend "s3" {}
+}`,
+				".terraform/terraform.tfstate": `{"version":3,"backend":{"type":"s3","config":{"bucket":"my-bucket","key":"terraform.tfstate","workspace_key_prefix":"workspaces","region":null},"hash":1}}`,
+				".terraform/environment":       "staging",
+			},
+			env: map[string]string{"TF_WORKSPACE": "prod"},
+			want: func(dir string) *config.SupplierConfig {
+				return &config.SupplierConfig{Key: "tfstate", Backend: BackendKeyS3, Path: "my-bucket/workspaces/prod/terraform.tfstate"}
+			},
+		},
+		{
+			name: "test gcs backend",
+			files: map[string]string{
+				".terraform/terraform.tfstate": `{"backend":{"type":"gcs","config":{"bucket":"my-bucket","prefix":"states/app"}}}`,
+			},
+			want: func(dir string) *config.SupplierConfig {
+				return &config.SupplierConfig{Key: "tfstate", Backend: BackendKeyGS, Path: "my-bucket/states/app/default.tfstate"}
+			},
+		},
+		{
+			name: "test azurerm backend with selected workspace",
+			files: map[string]string{
+				"main.tf": `
+terraform {
+  backend "azurerm" {
+    storage_account_name = "account"
+    container_name       = "tfstate"
+    key                  = "prod.terraform.tfstate"
+  }
+}`,
+				".terraform/environment": "staging",
+			},
+			want: func(dir string) *config.SupplierConfig {
+				return &config.SupplierConfig{Key: "tfstate", Backend: BackendKeyAzureRM, Path: "account/tfstate/prod.terraform.tfstateenv:staging"}
+			},
+		},
+		{
+			name: "test http backend",
+			files: map[string]string{
+				"main.tf": `
+terraform {
+  backend "http" {
+    address = "https://example.com/states/app"
+  }
+}`,
+			},
+			want: func(dir string) *config.SupplierConfig {
+				return &config.SupplierConfig{Key: "tfstate", Backend: BackendKeyHTTPS, Path: "example.com/states/app"}
+			},
+		},
+		{
+			name: "test remote backend with workspace prefix",
+			files: map[string]string{
+				"main.tf": `
+terraform {
+  backend "remote" {
+    organization = "my-org"
+    workspaces {
+      prefix = "app-"
+    }
+  }
+}`,
+				".terraform/environment": "prod",
+			},
+			want: func(dir string) *config.SupplierConfig {
+				return &config.SupplierConfig{Key: "tfstate", Backend: BackendKeyTFCloud, Path: "org/my-org?name=app-prod"}
+			},
+		},
+		{
+			name: "test initialized remote backend with workspace name",
+			files: map[string]string{
+				".terraform/terraform.tfstate": `{"backend":{"type":"remote","config":{"hostname":null,"organization":"my-org","workspaces":[{"name":"app","prefix":null}]}}}`,
+			},
+			want: func(dir string) *config.SupplierConfig {
+				return &config.SupplierConfig{Key: "tfstate", Backend: BackendKeyTFCloud, Path: "org/my-org?name=app"}
+			},
+		},
+		{
+			name: "test cloud block with workspace tags",
+			files: map[string]string{
+				"main.tf": `
+terraform {
+  cloud {
+    organization = "my-org"
+    workspaces {
+      tags = ["app", "network"]
+    }
+  }
+}`,
+			},
+			want: func(dir string) *config.SupplierConfig {
+				return &config.SupplierConfig{Key: "tfstate", Backend: BackendKeyTFCloud, Path: "org/my-org?tags=app%2Cnetwork"}
+			},
+		},
+		{
+			name: "test unsupported backend",
+			files: map[string]string{
+				"main.tf": `
+terraform {
+  backend "consul" {
+    path = "app"
+  }
+}`,
+			},
+			err: "Unsupported backend 'consul' in ",
+		},
+		{
+			name: "test invalid sources",
+			files: map[string]string{
+				"main.tf": `terraform {`,
+			},
+			err: "Unable to read backend configuration of ",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tt.files {
+				path := filepath.Join(dir, name)
+				if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			for key, value := range tt.env {
+				os.Setenv(key, value)
+				defer os.Unsetenv(key)
+			}
+
+			got, err := ResolveWorkdir(dir)
+			if tt.err != "" {
+				assert.NotNil(t, err)
+				assert.Contains(t, err.Error(), tt.err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want(dir), got)
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package enumerator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"github.com/cloudskiff/driftctl/pkg/envproxy"
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	"github.com/cloudskiff/driftctl/pkg/iac/terraform/state/backend"
+	"github.com/pkg/errors"
+)
+
+// azurermWorkspaceSeparator is appended by the azurerm Terraform backend to
+// the state key, followed by the workspace name, for non default workspaces
+const azurermWorkspaceSeparator = "env:"
+
+type AzureRMEnumerator struct {
+	config config.SupplierConfig
+	client backend.AzureBlobClient
+}
+
+func NewAzureRMEnumerator(config config.SupplierConfig) *AzureRMEnumerator {
+	return &AzureRMEnumerator{
+		config: config,
+	}
+}
+
+func (s *AzureRMEnumerator) Origin() string {
+	return s.config.String()
+}
+
+func (s *AzureRMEnumerator) Enumerate() ([]string, error) {
+	accountContainerPath := strings.SplitN(s.config.Path, "/", 3)
+	if len(accountContainerPath) < 3 {
+		return nil, errors.Errorf("Unable to parse azurerm path: %s. Must be STORAGE_ACCOUNT/CONTAINER/PREFIX", s.config.Path)
+	}
+
+	// The client is created lazily as it fails without Azure credentials
+	if s.client == nil {
+		envProxy := envproxy.NewEnvProxy("DCTL_AZURERM_", "AZURE_")
+		envProxy.Apply()
+		client, err := backend.NewAzureBlobClient()
+		envProxy.Restore()
+		if err != nil {
+			return nil, err
+		}
+		s.client = client
+	}
+
+	account, container := accountContainerPath[0], accountContainerPath[1]
+	// prefix should contains everything that does not have a glob pattern
+	// Pattern should be the glob matcher string
+	prefix, pattern := GlobS3(accountContainerPath[2])
+
+	fullPattern := strings.Join([]string{prefix, pattern}, "/")
+	fullPattern = strings.Trim(fullPattern, "/")
+
+	files := make([]string, 0)
+	pager := s.client.List(account, container, prefix)
+	for pager.NextPage(context.Background()) {
+		for _, blob := range pager.PageResponse() {
+			if blob.Size > 0 && matchAzureRMKey(fullPattern, blob.Name) {
+				files = append(files, strings.Join([]string{account, container, blob.Name}, "/"))
+			}
+		}
+	}
+	if err := pager.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(files) == 0 {
+		return files, fmt.Errorf("no Terraform state was found in %s, exiting", s.config.Path)
+	}
+
+	return files, nil
+}
+
+// matchAzureRMKey matches the blob name, or the key of the default workspace
+// for states of other workspaces
+func matchAzureRMKey(pattern, name string) bool {
+	if match, _ := doublestar.Match(pattern, name); match {
+		return true
+	}
+	if i := strings.LastIndex(name, azurermWorkspaceSeparator); i > 0 {
+		match, _ := doublestar.Match(pattern, name[:i])
+		return match
+	}
+	return false
+}
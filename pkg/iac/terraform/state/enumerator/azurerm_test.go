@@ -0,0 +1,114 @@
+package enumerator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	"github.com/cloudskiff/driftctl/pkg/iac/terraform/state/backend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAzureRMEnumerator_Enumerate(t *testing.T) {
+	blobs := [][]backend.AzureBlob{
+		{
+			{Name: "a/nested/prefix/state1", Size: 5},
+			{Name: "a/nested/prefix/empty", Size: 0},
+			{Name: "a/nested/prefix/terraform.tfstate", Size: 5},
+		},
+		{
+			{Name: "a/nested/prefix/terraform.tfstateenv:staging", Size: 5},
+			{Name: "a/nested/prefix/folder1/terraform.tfstate", Size: 5},
+			{Name: "a/nested/prefix/folder1/terraform.tfstateenv:prod", Size: 5},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		config config.SupplierConfig
+		prefix string
+		err    error
+		want   []string
+		errStr string
+	}{
+		{
+			name: "test results with a direct key and its workspaces",
+			config: config.SupplierConfig{
+				Path: "account/container/a/nested/prefix/terraform.tfstate",
+			},
+			prefix: "a/nested/prefix/terraform.tfstate",
+			want: []string{
+				"account/container/a/nested/prefix/terraform.tfstate",
+				"account/container/a/nested/prefix/terraform.tfstateenv:staging",
+			},
+		},
+		{
+			name: "test results with glob",
+			config: config.SupplierConfig{
+				Path: "account/container/a/nested/prefix/**/*.tfstate",
+			},
+			prefix: "a/nested/prefix",
+			want: []string{
+				"account/container/a/nested/prefix/terraform.tfstate",
+				"account/container/a/nested/prefix/terraform.tfstateenv:staging",
+				"account/container/a/nested/prefix/folder1/terraform.tfstate",
+				"account/container/a/nested/prefix/folder1/terraform.tfstateenv:prod",
+			},
+		},
+		{
+			name: "test no results",
+			config: config.SupplierConfig{
+				Path: "account/container/a/nested/prefix/*.json",
+			},
+			prefix: "a/nested/prefix",
+			want:   []string{},
+			errStr: "no Terraform state was found in account/container/a/nested/prefix/*.json, exiting",
+		},
+		{
+			name: "test listing error",
+			config: config.SupplierConfig{
+				Path: "account/container/a/nested/prefix/*.tfstate",
+			},
+			prefix: "a/nested/prefix",
+			err:    errors.New("403 Forbidden AuthorizationFailure"),
+			errStr: "403 Forbidden AuthorizationFailure",
+		},
+		{
+			name: "test invalid path",
+			config: config.SupplierConfig{
+				Path: "account/container",
+			},
+			errStr: "Unable to parse azurerm path: account/container. Must be STORAGE_ACCOUNT/CONTAINER/PREFIX",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pager := &backend.MockAzureBlobListPager{}
+			if tt.err != nil {
+				pager.On("NextPage", mock.Anything).Return(false).Once()
+			} else {
+				pager.On("NextPage", mock.Anything).Return(true).Times(2)
+				pager.On("NextPage", mock.Anything).Return(false).Once()
+				pager.On("PageResponse").Return(blobs[0]).Once()
+				pager.On("PageResponse").Return(blobs[1]).Once()
+			}
+			pager.On("Err").Return(tt.err)
+
+			client := &backend.MockAzureBlobClient{}
+			client.On("List", "account", "container", tt.prefix).Return(pager)
+
+			s := &AzureRMEnumerator{
+				config: tt.config,
+				client: client,
+			}
+			got, err := s.Enumerate()
+			if tt.errStr != "" {
+				assert.EqualError(t, err, tt.errStr)
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
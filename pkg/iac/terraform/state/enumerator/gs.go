@@ -0,0 +1,80 @@
+package enumerator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"cloud.google.com/go/storage"
+	"github.com/bmatcuk/doublestar/v4"
+	"github.com/cloudskiff/driftctl/pkg/envproxy"
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	"github.com/pkg/errors"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+)
+
+type GSEnumerator struct {
+	config config.SupplierConfig
+	client *storage.Client
+}
+
+func NewGSEnumerator(config config.SupplierConfig) *GSEnumerator {
+	return &GSEnumerator{
+		config: config,
+	}
+}
+
+func (s *GSEnumerator) Origin() string {
+	return s.config.String()
+}
+
+func (s *GSEnumerator) Enumerate() ([]string, error) {
+	bucketPath := strings.Split(s.config.Path, "/")
+	if len(bucketPath) < 2 {
+		return nil, errors.Errorf("Unable to parse GCS path: %s. Must be BUCKET_NAME/PREFIX", s.config.Path)
+	}
+
+	// The client is created lazily as it fails without Google credentials
+	if s.client == nil {
+		envProxy := envproxy.NewEnvProxy("DCTL_GS_", "GOOGLE_")
+		envProxy.Apply()
+		client, err := storage.NewClient(context.Background(), option.WithScopes(storage.ScopeReadOnly))
+		envProxy.Restore()
+		if err != nil {
+			return nil, err
+		}
+		s.client = client
+	}
+
+	bucket := bucketPath[0]
+	// prefix should contains everything that does not have a glob pattern
+	// Pattern should be the glob matcher string
+	prefix, pattern := GlobS3(strings.Join(bucketPath[1:], "/"))
+
+	fullPattern := strings.Join([]string{prefix, pattern}, "/")
+	fullPattern = strings.Trim(fullPattern, "/")
+
+	files := make([]string, 0)
+	it := s.client.Bucket(bucket).Objects(context.Background(), &storage.Query{Prefix: prefix})
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if attrs.Size > 0 {
+			if match, _ := doublestar.Match(fullPattern, attrs.Name); match {
+				files = append(files, strings.Join([]string{bucket, attrs.Name}, "/"))
+			}
+		}
+	}
+
+	if len(files) == 0 {
+		return files, fmt.Errorf("no Terraform state was found in %s, exiting", s.config.Path)
+	}
+
+	return files, nil
+}
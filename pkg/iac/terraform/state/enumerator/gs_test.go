@@ -0,0 +1,94 @@
+package enumerator
+
+import (
+	"testing"
+
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	googletest "github.com/cloudskiff/driftctl/test/google"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGSEnumerator_Enumerate(t *testing.T) {
+	client, err := googletest.NewFakeStorageServer(t, map[string]string{
+		"bucket-name/a/nested/prefix/state1":                    "{}",
+		"bucket-name/a/nested/prefix/state2":                    "{}",
+		"bucket-name/a/nested/prefix/empty":                     "",
+		"bucket-name/a/nested/prefix/folder1/state3":            "{}",
+		"bucket-name/a/nested/prefix/folder2/subfolder1/state4": "{}",
+		"bucket-name/a/nested/prefix/state.tfstate":             "{}",
+		"bucket-name/a/nested/prefix/folder1/state.tfstate":     "{}",
+		"bucket-name/another/prefix/state5":                     "{}",
+		"other-bucket/a/nested/prefix/state6":                   "{}",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		config config.SupplierConfig
+		want   []string
+		err    string
+	}{
+		{
+			name: "test results with a direct object",
+			config: config.SupplierConfig{
+				Path: "bucket-name/a/nested/prefix/state1",
+			},
+			want: []string{
+				"bucket-name/a/nested/prefix/state1",
+			},
+		},
+		{
+			name: "test results with glob",
+			config: config.SupplierConfig{
+				Path: "bucket-name/a/nested/prefix/**/*.tfstate",
+			},
+			want: []string{
+				"bucket-name/a/nested/prefix/folder1/state.tfstate",
+				"bucket-name/a/nested/prefix/state.tfstate",
+			},
+		},
+		{
+			name: "test results with simple wildcard skipping empty objects",
+			config: config.SupplierConfig{
+				Path: "bucket-name/a/nested/prefix/*",
+			},
+			want: []string{
+				"bucket-name/a/nested/prefix/state.tfstate",
+				"bucket-name/a/nested/prefix/state1",
+				"bucket-name/a/nested/prefix/state2",
+			},
+		},
+		{
+			name: "test no results",
+			config: config.SupplierConfig{
+				Path: "bucket-name/a/nested/prefix/*.json",
+			},
+			want: []string{},
+			err:  "no Terraform state was found in bucket-name/a/nested/prefix/*.json, exiting",
+		},
+		{
+			name: "test invalid path",
+			config: config.SupplierConfig{
+				Path: "bucket-name",
+			},
+			err: "Unable to parse GCS path: bucket-name. Must be BUCKET_NAME/PREFIX",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &GSEnumerator{
+				config: tt.config,
+				client: client,
+			}
+			got, err := s.Enumerate()
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
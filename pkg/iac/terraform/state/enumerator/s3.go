@@ -2,6 +2,8 @@ package enumerator
 
 import (
 	"fmt"
+	"net/url"
+	"path"
 	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -15,9 +17,25 @@ import (
 	"github.com/cloudskiff/driftctl/pkg/iac/config"
 )
 
+// s3DefaultWorkspaceKeyPrefix is the workspace_key_prefix default of the S3
+// Terraform backend
+const s3DefaultWorkspaceKeyPrefix = "env:"
+
+const defaultWorkspace = "default"
+
+// s3WorkspacesParameter is the query parameter enabling the workspace mode, a
+// ? alone in a path remains a glob matching any single character
+const s3WorkspacesParameter = "workspaces"
+
+// S3Enumerator lists states matching a glob pattern, e.g. bucket/**/*.tfstate
+// or, only when the workspaces query parameter is set, the states of every
+// workspace of a single key, e.g.
+// bucket/path/terraform.tfstate?workspaces=*&workspace_key_prefix=env:
 type S3Enumerator struct {
 	config config.SupplierConfig
 	client s3iface.S3API
+	// workspaces maps enumerated keys to their workspace in workspace mode
+	workspaces map[string]string
 }
 
 func NewS3Enumerator(config config.SupplierConfig) *S3Enumerator {
@@ -28,8 +46,8 @@ func NewS3Enumerator(config config.SupplierConfig) *S3Enumerator {
 	}))
 	envProxy.Restore()
 	return &S3Enumerator{
-		config,
-		s3.New(sess),
+		config: config,
+		client: s3.New(sess),
 	}
 }
 
@@ -37,7 +55,16 @@ func (s *S3Enumerator) Origin() string {
 	return s.config.String()
 }
 
+// Workspace returns the Terraform workspace of an enumerated key, if known
+func (s *S3Enumerator) Workspace(key string) string {
+	return s.workspaces[key]
+}
+
 func (s *S3Enumerator) Enumerate() ([]string, error) {
+	if key, query, ok := splitWorkspacesPath(s.config.Path); ok {
+		return s.enumerateWorkspaces(key, query)
+	}
+
 	bucketPath := strings.Split(s.config.Path, "/")
 	if len(bucketPath) < 2 {
 		return nil, errors.Errorf("Unable to parse S3 path: %s. Must be BUCKET_NAME/PREFIX", s.config.Path)
@@ -77,3 +104,96 @@ func (s *S3Enumerator) Enumerate() ([]string, error) {
 
 	return files, nil
 }
+
+// enumerateWorkspaces mimics the S3 Terraform backend layout: the default
+// workspace is stored at the key itself and other workspaces at
+// <workspace_key_prefix>/<workspace>/<key>
+func (s *S3Enumerator) enumerateWorkspaces(bucketKey string, query url.Values) ([]string, error) {
+	bucketPath := strings.SplitN(bucketKey, "/", 2)
+	if len(bucketPath) < 2 || bucketPath[1] == "" {
+		return nil, errors.Errorf("Unable to parse S3 path: %s. Must be BUCKET_NAME/KEY?workspaces=GLOB&workspace_key_prefix=PREFIX", s.config.Path)
+	}
+	bucket, key := bucketPath[0], bucketPath[1]
+
+	pattern := query.Get(s3WorkspacesParameter)
+	if pattern == "" {
+		pattern = "*"
+	}
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, errors.Errorf("Invalid workspace name pattern '%s': %s", pattern, err)
+	}
+	workspaceKeyPrefix := s3DefaultWorkspaceKeyPrefix
+	if prefix, ok := query["workspace_key_prefix"]; ok {
+		workspaceKeyPrefix = prefix[0]
+	}
+
+	files := make([]string, 0)
+	s.workspaces = map[string]string{}
+	addState := func(workspace, stateKey string) {
+		if match, _ := path.Match(pattern, workspace); !match {
+			return
+		}
+		file := strings.Join([]string{bucket, stateKey}, "/")
+		files = append(files, file)
+		s.workspaces[file] = workspace
+	}
+
+	err := s.listObjects(bucket, key, func(object *s3.Object) {
+		if aws.StringValue(object.Key) == key {
+			addState(defaultWorkspace, key)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := workspaceKeyPrefix + "/"
+	err = s.listObjects(bucket, prefix, func(object *s3.Object) {
+		parts := strings.SplitN(strings.TrimPrefix(aws.StringValue(object.Key), prefix), "/", 2)
+		if len(parts) == 2 && parts[0] != "" && parts[1] == key {
+			addState(parts[0], aws.StringValue(object.Key))
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(files) == 0 {
+		return files, fmt.Errorf("no Terraform state was found in %s, exiting", s.config.Path)
+	}
+
+	return files, nil
+}
+
+// splitWorkspacesPath splits a path into its bucket and key and its query
+// parameters, when they explicitly enable the workspace mode
+func splitWorkspacesPath(p string) (string, url.Values, bool) {
+	index := strings.LastIndex(p, "?")
+	if index < 0 {
+		return "", nil, false
+	}
+	query, err := url.ParseQuery(p[index+1:])
+	if err != nil {
+		return "", nil, false
+	}
+	if _, exist := query[s3WorkspacesParameter]; !exist {
+		return "", nil, false
+	}
+	return p[:index], query, true
+}
+
+// listObjects calls fn for every non empty object under the given prefix
+func (s *S3Enumerator) listObjects(bucket, prefix string, fn func(object *s3.Object)) error {
+	input := &s3.ListObjectsV2Input{
+		Bucket: &bucket,
+		Prefix: &prefix,
+	}
+	return s.client.ListObjectsV2Pages(input, func(output *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, metadata := range output.Contents {
+			if aws.Int64Value(metadata.Size) > 0 {
+				fn(metadata)
+			}
+		}
+		return !lastPage
+	})
+}
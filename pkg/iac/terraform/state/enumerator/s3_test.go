@@ -530,6 +530,38 @@ func TestS3Enumerator_Enumerate(t *testing.T) {
 			},
 			want: []string{"bucket-name/a/nested/prefix/terraform.tfstate/terraform.tfstate"},
 		},
+		{
+			name: "single character glob does not enable the workspace mode",
+			config: config.SupplierConfig{
+				Path: "bucket-name/states/state-?.tfstate",
+			},
+			mocks: func(client *awstest.MockFakeS3) {
+				input := &s3.ListObjectsV2Input{
+					Bucket: awssdk.String("bucket-name"),
+					Prefix: awssdk.String("states"),
+				}
+				client.On(
+					"ListObjectsV2Pages",
+					input,
+					mock.MatchedBy(func(callback func(res *s3.ListObjectsV2Output, lastPage bool) bool) bool {
+						callback(&s3.ListObjectsV2Output{
+							Contents: []*s3.Object{
+								{
+									Key:  awssdk.String("states/state-a.tfstate"),
+									Size: awssdk.Int64(5),
+								},
+								{
+									Key:  awssdk.String("states/state-ab.tfstate"),
+									Size: awssdk.Int64(5),
+								},
+							},
+						}, true)
+						return true
+					}),
+				).Return(nil)
+			},
+			want: []string{"bucket-name/states/state-a.tfstate"},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -554,3 +586,139 @@ func TestS3Enumerator_Enumerate(t *testing.T) {
 		})
 	}
 }
+
+func TestS3Enumerator_EnumerateWorkspaces(t *testing.T) {
+	mockList := func(client *awstest.MockFakeS3, prefix string, keys ...string) {
+		contents := make([]*s3.Object, 0, len(keys))
+		for _, key := range keys {
+			contents = append(contents, &s3.Object{Key: awssdk.String(key), Size: awssdk.Int64(5)})
+		}
+		client.On(
+			"ListObjectsV2Pages",
+			&s3.ListObjectsV2Input{
+				Bucket: awssdk.String("bucket-name"),
+				Prefix: awssdk.String(prefix),
+			},
+			mock.MatchedBy(func(callback func(res *s3.ListObjectsV2Output, lastPage bool) bool) bool {
+				callback(&s3.ListObjectsV2Output{Contents: contents}, true)
+				return true
+			}),
+		).Return(nil)
+	}
+
+	tests := []struct {
+		name           string
+		config         config.SupplierConfig
+		mocks          func(client *awstest.MockFakeS3)
+		want           []string
+		wantWorkspaces map[string]string
+		err            string
+	}{
+		{
+			name: "default and every workspace",
+			config: config.SupplierConfig{
+				Path: "bucket-name/stack/terraform.tfstate?workspaces",
+			},
+			mocks: func(client *awstest.MockFakeS3) {
+				mockList(client, "stack/terraform.tfstate",
+					"stack/terraform.tfstate",
+					"stack/terraform.tfstate.backup",
+				)
+				mockList(client, "env:/",
+					"env:/staging/stack/terraform.tfstate",
+					"env:/prod/stack/terraform.tfstate",
+					"env:/prod/other/terraform.tfstate",
+					"env:/prod/nested/stack/terraform.tfstate",
+				)
+			},
+			want: []string{
+				"bucket-name/stack/terraform.tfstate",
+				"bucket-name/env:/staging/stack/terraform.tfstate",
+				"bucket-name/env:/prod/stack/terraform.tfstate",
+			},
+			wantWorkspaces: map[string]string{
+				"bucket-name/stack/terraform.tfstate":              "default",
+				"bucket-name/env:/staging/stack/terraform.tfstate": "staging",
+				"bucket-name/env:/prod/stack/terraform.tfstate":    "prod",
+			},
+		},
+		{
+			name: "custom workspace key prefix and workspace pattern",
+			config: config.SupplierConfig{
+				Path: "bucket-name/terraform.tfstate?workspaces=prod-*&workspace_key_prefix=workspaces/team-a",
+			},
+			mocks: func(client *awstest.MockFakeS3) {
+				mockList(client, "terraform.tfstate", "terraform.tfstate")
+				mockList(client, "workspaces/team-a/",
+					"workspaces/team-a/prod-eu/terraform.tfstate",
+					"workspaces/team-a/prod-us/terraform.tfstate",
+					"workspaces/team-a/staging/terraform.tfstate",
+				)
+			},
+			want: []string{
+				"bucket-name/workspaces/team-a/prod-eu/terraform.tfstate",
+				"bucket-name/workspaces/team-a/prod-us/terraform.tfstate",
+			},
+			wantWorkspaces: map[string]string{
+				"bucket-name/workspaces/team-a/prod-eu/terraform.tfstate": "prod-eu",
+				"bucket-name/workspaces/team-a/prod-us/terraform.tfstate": "prod-us",
+			},
+		},
+		{
+			name: "no workspace found",
+			config: config.SupplierConfig{
+				Path: "bucket-name/terraform.tfstate?workspaces=*",
+			},
+			mocks: func(client *awstest.MockFakeS3) {
+				mockList(client, "terraform.tfstate")
+				mockList(client, "env:/")
+			},
+			want: []string{},
+			err:  "no Terraform state was found in bucket-name/terraform.tfstate?workspaces=*, exiting",
+		},
+		{
+			name: "missing key",
+			config: config.SupplierConfig{
+				Path: "bucket-name?workspaces=*",
+			},
+			mocks: func(client *awstest.MockFakeS3) {},
+			err:   "Unable to parse S3 path: bucket-name?workspaces=*. Must be BUCKET_NAME/KEY?workspaces=GLOB&workspace_key_prefix=PREFIX",
+		},
+		{
+			name: "invalid workspace pattern",
+			config: config.SupplierConfig{
+				Path: "bucket-name/terraform.tfstate?workspaces=[",
+			},
+			mocks: func(client *awstest.MockFakeS3) {},
+			err:   "Invalid workspace name pattern '[': syntax error in pattern",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeS3 := awstest.MockFakeS3{}
+			tt.mocks(&fakeS3)
+			s := &S3Enumerator{
+				config: tt.config,
+				client: &fakeS3,
+			}
+			got, err := s.Enumerate()
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Fatalf("Expected error '%s', got '%v'", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error '%s'", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Enumerate() got = %v, want %v", got, tt.want)
+			}
+			for key, workspace := range tt.wantWorkspaces {
+				if got := s.Workspace(key); got != workspace {
+					t.Errorf("Workspace(%s) got = %s, want %s", key, got, workspace)
+				}
+			}
+		})
+	}
+}
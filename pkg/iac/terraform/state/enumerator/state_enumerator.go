@@ -1,6 +1,8 @@
 package enumerator
 
 import (
+	"strings"
+
 	"github.com/cloudskiff/driftctl/pkg/iac/config"
 	"github.com/cloudskiff/driftctl/pkg/iac/terraform/state/backend"
 	"github.com/sirupsen/logrus"
@@ -11,13 +13,27 @@ type StateEnumerator interface {
 	Enumerate() ([]string, error)
 }
 
-func GetEnumerator(config config.SupplierConfig) StateEnumerator {
+// WorkspaceEnumerator is implemented by enumerators knowing the Terraform
+// workspace of the keys they enumerate
+type WorkspaceEnumerator interface {
+	Workspace(key string) string
+}
+
+func GetEnumerator(config config.SupplierConfig, opts *backend.Options) StateEnumerator {
 
 	switch config.Backend {
 	case backend.BackendKeyFile:
 		return NewFileEnumerator(config)
 	case backend.BackendKeyS3:
 		return NewS3Enumerator(config)
+	case backend.BackendKeyGS:
+		return NewGSEnumerator(config)
+	case backend.BackendKeyAzureRM:
+		return NewAzureRMEnumerator(config)
+	case backend.BackendKeyTFCloud:
+		if strings.HasPrefix(config.Path, TFCloudOrganizationPrefix) {
+			return NewTFCloudEnumerator(config, opts)
+		}
 	}
 
 	logrus.WithFields(logrus.Fields{
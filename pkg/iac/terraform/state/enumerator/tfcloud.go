@@ -0,0 +1,156 @@
+package enumerator
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+
+	pkghttp "github.com/cloudskiff/driftctl/pkg/http"
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	"github.com/cloudskiff/driftctl/pkg/iac/terraform/state/backend"
+	"github.com/pkg/errors"
+)
+
+// TFCloudOrganizationPrefix distinguishes organization paths to enumerate
+// from plain workspace ids
+const TFCloudOrganizationPrefix = "org/"
+
+const tfcloudPageSize = 100
+
+type tfcloudWorkspacesBody struct {
+	Data []struct {
+		Id         string `json:"id"`
+		Attributes struct {
+			Name string `json:"name"`
+		} `json:"attributes"`
+		Relationships struct {
+			CurrentStateVersion struct {
+				Data *struct {
+					Id string `json:"id"`
+				} `json:"data"`
+			} `json:"current-state-version"`
+		} `json:"relationships"`
+	} `json:"data"`
+	Meta struct {
+		Pagination struct {
+			NextPage *int `json:"next-page"`
+		} `json:"pagination"`
+	} `json:"meta"`
+}
+
+// TFCloudEnumerator lists workspaces of a Terraform Cloud organization, e.g.
+// org/my-org?tags=prod,network&name=team-a-*
+// Enumerated keys are workspace ids read by the tfcloud backend.
+type TFCloudEnumerator struct {
+	config config.SupplierConfig
+	opts   *backend.Options
+	client pkghttp.HTTPClient
+}
+
+func NewTFCloudEnumerator(config config.SupplierConfig, opts *backend.Options) *TFCloudEnumerator {
+	return &TFCloudEnumerator{
+		config: config,
+		opts:   opts,
+		client: &http.Client{},
+	}
+}
+
+func (s *TFCloudEnumerator) Origin() string {
+	return s.config.String()
+}
+
+func (s *TFCloudEnumerator) Enumerate() ([]string, error) {
+	u, err := url.Parse(strings.TrimPrefix(s.config.Path, TFCloudOrganizationPrefix))
+	if err != nil {
+		return nil, err
+	}
+	organization := u.Path
+	if organization == "" || strings.Contains(organization, "/") {
+		return nil, errors.Errorf("Unable to parse Terraform Cloud path: %s. Must be org/ORGANIZATION?tags=TAG1,TAG2&name=GLOB", s.config.Path)
+	}
+	tags := u.Query().Get("tags")
+	namePattern := u.Query().Get("name")
+	if _, err := path.Match(namePattern, ""); err != nil {
+		return nil, errors.Errorf("Invalid workspace name pattern '%s': %s", namePattern, err)
+	}
+
+	endpoint := fmt.Sprintf("%s/organizations/%s/workspaces", s.opts.TFCloudEndpoint, url.PathEscape(organization))
+	endpointURL, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	token, err := backend.GetTFCloudToken(s.opts, endpointURL.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]string, 0)
+	for page := 1; ; {
+		query := url.Values{
+			"page[number]": []string{strconv.Itoa(page)},
+			"page[size]":   []string{strconv.Itoa(tfcloudPageSize)},
+		}
+		if tags != "" {
+			query.Set("search[tags]", tags)
+		}
+		endpointURL.RawQuery = query.Encode()
+
+		body, err := s.listWorkspaces(endpointURL.String(), token)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, workspace := range body.Data {
+			// Workspaces without any applied run have no state to read
+			if workspace.Relationships.CurrentStateVersion.Data == nil {
+				continue
+			}
+			if namePattern != "" {
+				if match, _ := path.Match(namePattern, workspace.Attributes.Name); !match {
+					continue
+				}
+			}
+			files = append(files, workspace.Id)
+		}
+
+		if body.Meta.Pagination.NextPage == nil {
+			break
+		}
+		page = *body.Meta.Pagination.NextPage
+	}
+
+	if len(files) == 0 {
+		return files, fmt.Errorf("no Terraform state was found in %s, exiting", s.config.Path)
+	}
+
+	return files, nil
+}
+
+func (s *TFCloudEnumerator) listWorkspaces(endpoint, token string) (*tfcloudWorkspacesBody, error) {
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Content-Type", "application/vnd.api+json")
+	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token))
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 400 {
+		return nil, errors.Errorf("error requesting terraform cloud workspaces: status code: %d", res.StatusCode)
+	}
+
+	body := &tfcloudWorkspacesBody{}
+	if err := json.NewDecoder(res.Body).Decode(body); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
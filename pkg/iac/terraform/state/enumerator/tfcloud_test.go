@@ -0,0 +1,126 @@
+package enumerator
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/cloudskiff/driftctl/pkg/iac/config"
+	"github.com/cloudskiff/driftctl/pkg/iac/terraform/state/backend"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTFCloudEnumerator_Enumerate(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	opts := &backend.Options{
+		TFCloudToken:    "TOKEN",
+		TFCloudEndpoint: "https://app.terraform.io/api/v2",
+	}
+
+	tests := []struct {
+		name string
+		path string
+		mock func()
+		want []string
+		err  string
+	}{
+		{
+			name: "test results with pagination",
+			path: "org/my-org",
+			mock: func() {
+				httpmock.RegisterResponderWithQuery(
+					"GET",
+					"https://app.terraform.io/api/v2/organizations/my-org/workspaces",
+					"page%5Bnumber%5D=1&page%5Bsize%5D=100",
+					httpmock.NewStringResponder(http.StatusOK, `{"data":[
+						{"id":"ws-1","attributes":{"name":"team-a-network"},"relationships":{"current-state-version":{"data":{"id":"sv-1"}}}},
+						{"id":"ws-2","attributes":{"name":"team-a-empty"},"relationships":{"current-state-version":{"data":null}}}
+					],"meta":{"pagination":{"next-page":2}}}`),
+				)
+				httpmock.RegisterResponderWithQuery(
+					"GET",
+					"https://app.terraform.io/api/v2/organizations/my-org/workspaces",
+					"page%5Bnumber%5D=2&page%5Bsize%5D=100",
+					httpmock.NewStringResponder(http.StatusOK, `{"data":[
+						{"id":"ws-3","attributes":{"name":"team-b-network"},"relationships":{"current-state-version":{"data":{"id":"sv-3"}}}}
+					],"meta":{"pagination":{"next-page":null}}}`),
+				)
+			},
+			want: []string{"ws-1", "ws-3"},
+		},
+		{
+			name: "test results filtered by tags and name",
+			path: "org/my-org?tags=prod,network&name=team-a-*",
+			mock: func() {
+				httpmock.RegisterResponderWithQuery(
+					"GET",
+					"https://app.terraform.io/api/v2/organizations/my-org/workspaces",
+					"page%5Bnumber%5D=1&page%5Bsize%5D=100&search%5Btags%5D=prod%2Cnetwork",
+					httpmock.NewStringResponder(http.StatusOK, `{"data":[
+						{"id":"ws-1","attributes":{"name":"team-a-network"},"relationships":{"current-state-version":{"data":{"id":"sv-1"}}}},
+						{"id":"ws-3","attributes":{"name":"team-b-network"},"relationships":{"current-state-version":{"data":{"id":"sv-3"}}}}
+					],"meta":{"pagination":{"next-page":null}}}`),
+				)
+			},
+			want: []string{"ws-1"},
+		},
+		{
+			name: "test no results",
+			path: "org/my-org?name=unknown",
+			mock: func() {
+				httpmock.RegisterResponderWithQuery(
+					"GET",
+					"https://app.terraform.io/api/v2/organizations/my-org/workspaces",
+					"page%5Bnumber%5D=1&page%5Bsize%5D=100",
+					httpmock.NewStringResponder(http.StatusOK, `{"data":[],"meta":{"pagination":{"next-page":null}}}`),
+				)
+			},
+			want: []string{},
+			err:  "no Terraform state was found in org/my-org?name=unknown, exiting",
+		},
+		{
+			name: "test API error",
+			path: "org/unknown-org",
+			mock: func() {
+				httpmock.RegisterResponder(
+					"GET",
+					"https://app.terraform.io/api/v2/organizations/unknown-org/workspaces",
+					httpmock.NewStringResponder(http.StatusNotFound, `{"errors":[{"status":"404","title":"not found"}]}`),
+				)
+			},
+			err: "error requesting terraform cloud workspaces: status code: 404",
+		},
+		{
+			name: "test invalid path",
+			path: "org/my-org/workspace",
+			mock: func() {},
+			err:  "Unable to parse Terraform Cloud path: org/my-org/workspace. Must be org/ORGANIZATION?tags=TAG1,TAG2&name=GLOB",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Reset()
+			tt.mock()
+
+			s := NewTFCloudEnumerator(config.SupplierConfig{
+				Key:     "tfstate",
+				Backend: backend.BackendKeyTFCloud,
+				Path:    tt.path,
+			}, opts)
+			got, err := s.Enumerate()
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGetEnumerator_TFCloud(t *testing.T) {
+	assert.Nil(t, GetEnumerator(config.SupplierConfig{Backend: backend.BackendKeyTFCloud, Path: "ws-123"}, &backend.Options{}))
+	assert.IsType(t, &TFCloudEnumerator{}, GetEnumerator(config.SupplierConfig{Backend: backend.BackendKeyTFCloud, Path: "org/my-org"}, &backend.Options{}))
+}
@@ -1,13 +1,16 @@
 package state
 
 import (
+	"context"
 	"fmt"
+	"runtime"
 	"strings"
 
 	"github.com/cloudskiff/driftctl/pkg/alerter"
 	"github.com/cloudskiff/driftctl/pkg/filter"
 	"github.com/cloudskiff/driftctl/pkg/iac"
 	"github.com/cloudskiff/driftctl/pkg/output"
+	"github.com/cloudskiff/driftctl/pkg/parallel"
 	"github.com/hashicorp/terraform/addrs"
 	"github.com/hashicorp/terraform/states"
 	"github.com/hashicorp/terraform/states/statefile"
@@ -34,7 +37,6 @@ type decodedRes struct {
 type TerraformStateReader struct {
 	library        *terraform.ProviderLibrary
 	config         config.SupplierConfig
-	backend        backend.Backend
 	enumerator     enumerator.StateEnumerator
 	deserializer   *resource.Deserializer
 	backendOptions *backend.Options
@@ -44,7 +46,18 @@ type TerraformStateReader struct {
 }
 
 func (r *TerraformStateReader) initReader() error {
-	r.enumerator = enumerator.GetEnumerator(r.config)
+	if r.config.Backend == backend.BackendKeyWorkdir {
+		resolved, err := backend.ResolveWorkdir(r.config.Path)
+		if err != nil {
+			return err
+		}
+		logrus.WithFields(logrus.Fields{
+			"workdir": r.config.Path,
+			"source":  resolved.String(),
+		}).Debug("Resolved state of Terraform working directory")
+		r.config = *resolved
+	}
+	r.enumerator = enumerator.GetEnumerator(r.config, r.backendOptions)
 	return nil
 }
 
@@ -57,15 +70,16 @@ func NewReader(config config.SupplierConfig, library *terraform.ProviderLibrary,
 	return &reader, nil
 }
 
-func (r *TerraformStateReader) retrieve() (map[string][]decodedRes, error) {
-	b, err := backend.GetBackend(r.config, r.backendOptions)
+// retrieve reads a single state, it does not mutate the reader so states can
+// be read concurrently
+func (r *TerraformStateReader) retrieve(config config.SupplierConfig, workspace string) (map[string][]decodedRes, error) {
+	b, err := backend.GetBackend(config, r.backendOptions)
 	if err != nil {
 		return nil, err
 	}
-	r.backend = b
 
-	state, err := read(r.config.Path, r.backend)
-	defer r.backend.Close()
+	state, err := read(config.Path, b)
+	defer b.Close()
 	if err != nil {
 		return nil, err
 	}
@@ -140,8 +154,10 @@ func (r *TerraformStateReader) retrieve() (map[string][]decodedRes, error) {
 					}
 				}
 				_, exists := resMap[stateRes.Addr.Resource.Type]
+				source := resource.NewTerraformStateSource(config.String(), moduleName, resName)
+				source.WorkspaceName = workspace
 				val := decodedRes{
-					source: resource.NewTerraformStateSource(r.config.String(), moduleName, resName),
+					source: source,
 					val:    decodedVal.Value,
 				}
 				if !exists {
@@ -208,25 +224,30 @@ func (r *TerraformStateReader) decode(valFromState map[string][]decodedRes) ([]*
 
 func (r *TerraformStateReader) Resources() ([]*resource.Resource, error) {
 	if r.enumerator == nil {
-		return r.retrieveForState(r.config.Path)
+		return r.retrieveForState(r.config, "")
 	}
 
 	return r.retrieveMultiplesStates()
 }
 
-func (r *TerraformStateReader) retrieveForState(path string) ([]*resource.Resource, error) {
-	r.config.Path = path
+func (r *TerraformStateReader) retrieveForState(config config.SupplierConfig, workspace string) ([]*resource.Resource, error) {
 	logrus.WithFields(logrus.Fields{
-		"path":    r.config.Path,
-		"backend": r.config.Backend,
+		"path":    config.Path,
+		"backend": config.Backend,
 	}).Debug("Reading resources from state")
 	r.progress.Inc()
-	values, err := r.retrieve()
+	values, err := r.retrieve(config, workspace)
 	if err != nil {
-		return nil, errors.Wrap(err, r.config.String())
+		return nil, errors.Wrap(err, config.String())
 	}
 	decode, err := r.decode(values)
-	return decode, errors.Wrap(err, r.config.String())
+	return decode, errors.Wrap(err, config.String())
+}
+
+type stateResult struct {
+	index     int
+	resources []*resource.Resource
+	err       error
 }
 
 func (r *TerraformStateReader) retrieveMultiplesStates() ([]*resource.Resource, error) {
@@ -240,19 +261,59 @@ func (r *TerraformStateReader) retrieveMultiplesStates() ([]*resource.Resource,
 		"keys": keys,
 	}).Debug("Enumerated keys")
 
+	workspaceEnumerator, _ := r.enumerator.(enumerator.WorkspaceEnumerator)
+	runner := parallel.NewParallelRunner(context.TODO(), int64(runtime.NumCPU()))
+	for i, key := range keys {
+		index := i
+		stateConfig := r.config
+		stateConfig.Path = key
+		workspace := ""
+		if workspaceEnumerator != nil {
+			workspace = workspaceEnumerator.Workspace(key)
+		}
+		runner.Run(func() (interface{}, error) {
+			resources, err := r.retrieveForState(stateConfig, workspace)
+			// Errors are aggregated below, returning them would stop the runner
+			return &stateResult{index, resources, err}, nil
+		})
+	}
+
+	// Results are stored by key position so resources and errors are always
+	// reported in the enumeration order
+	stateResults := make([]*stateResult, len(keys))
+ReadLoop:
+	for {
+		select {
+		case res, ok := <-runner.Read():
+			if !ok || res == nil {
+				break ReadLoop
+			}
+			result, _ := res.(*stateResult)
+			stateResults[result.index] = result
+		case <-runner.DoneChan():
+			break ReadLoop
+		}
+	}
+
+	if runner.Err() != nil {
+		return nil, runner.Err()
+	}
+
 	results := make([]*resource.Resource, 0)
 	isSuccess := false
 	readingError := iac.NewStateReadingError()
 
-	for _, key := range keys {
-		resources, err := r.retrieveForState(key)
-		if err != nil {
-			readingError.Add(err)
-			r.alerter.SendAlert("", NewStateReadingAlert(key, err))
+	for i, result := range stateResults {
+		if result == nil {
+			continue
+		}
+		if result.err != nil {
+			readingError.Add(result.err)
+			r.alerter.SendAlert("", NewStateReadingAlert(keys[i], result.err))
 			continue
 		}
 		isSuccess = true
-		results = append(results, resources...)
+		results = append(results, result.resources...)
 	}
 
 	if !isSuccess {
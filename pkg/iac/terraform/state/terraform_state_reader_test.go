@@ -2,12 +2,19 @@ package state
 
 import (
 	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path"
 	"strings"
 	"testing"
 
+	"github.com/cloudskiff/driftctl/pkg/alerter"
 	"github.com/cloudskiff/driftctl/pkg/filter"
+	"github.com/cloudskiff/driftctl/pkg/iac"
+	"github.com/cloudskiff/driftctl/pkg/iac/terraform/state/backend"
+	"github.com/cloudskiff/driftctl/pkg/iac/terraform/state/enumerator"
 	"github.com/cloudskiff/driftctl/pkg/output"
 	"github.com/cloudskiff/driftctl/pkg/remote/azurerm"
 	"github.com/cloudskiff/driftctl/pkg/remote/google"
@@ -600,3 +607,132 @@ func TestTerraformStateReader_WithIgnoredResource(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Len(t, got, 0)
 }
+
+func TestTerraformStateReader_MultipleStates(t *testing.T) {
+	valid, err := os.ReadFile("testdata/v4/valid.tfstate")
+	assert.NoError(t, err)
+	invalid, err := os.ReadFile("testdata/v4/invalid.tfstate")
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		invalidState func(i int) bool
+		wantErr      bool
+	}{
+		{
+			name:         "some states cannot be read",
+			invalidState: func(i int) bool { return i%3 == 0 },
+		},
+		{
+			name:         "no state can be read",
+			invalidState: func(i int) bool { return true },
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for i := 0; i < 20; i++ {
+				content := valid
+				if tt.invalidState(i) {
+					content = invalid
+				}
+				assert.NoError(t, os.WriteFile(path.Join(dir, fmt.Sprintf("%02d.tfstate", i)), content, 0600))
+			}
+
+			stateConfig := config.SupplierConfig{
+				Key:  "tfstate",
+				Path: path.Join(dir, "*.tfstate"),
+			}
+			keys, err := enumerator.NewFileEnumerator(stateConfig).Enumerate()
+			assert.NoError(t, err)
+
+			progress := &output.MockProgress{}
+			progress.On("Inc").Return().Times(len(keys))
+			alerter := alerter.NewAlerter()
+
+			r, err := NewReader(stateConfig, terraform.NewProviderLibrary(), &backend.Options{}, progress, alerter, nil, nil)
+			assert.NoError(t, err)
+
+			_, err = r.Resources()
+			progress.AssertExpectations(t)
+
+			// Errors must be reported in the enumeration order whatever the
+			// order states were read in
+			failedKeys := make([]string, 0)
+			for i, key := range keys {
+				if tt.invalidState(i) {
+					failedKeys = append(failedKeys, key)
+				}
+			}
+			alertKeys := make([]string, 0)
+			for _, a := range alerter.Retrieve()[""] {
+				alertKeys = append(alertKeys, a.(*StateReadingAlert).key)
+			}
+			assert.Equal(t, failedKeys, alertKeys)
+
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+			_, ok := err.(*iac.StateReadingError)
+			assert.True(t, ok)
+			lastIndex := -1
+			for _, key := range failedKeys {
+				index := strings.Index(err.Error(), "tfstate://"+key+":")
+				assert.Greater(t, index, lastIndex, "%s error is not in enumeration order", key)
+				lastIndex = index
+			}
+		})
+	}
+}
+
+// Backends of remote states are created concurrently, this test must be run
+// with -race to catch clients created while the environment is restored
+func TestTerraformStateReader_MultipleStates_AzureRM(t *testing.T) {
+	valid, err := os.ReadFile("testdata/v4/valid.tfstate")
+	assert.NoError(t, err)
+
+	const stateCount = 20
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("sig") != "signature" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if r.URL.Path == "/container" {
+			blobs := ""
+			for i := 0; i < stateCount; i++ {
+				blobs += fmt.Sprintf("<Blob><Name>states/%02d.tfstate</Name><Properties><Content-Length>%d</Content-Length></Properties></Blob>", i, len(valid))
+			}
+			fmt.Fprintf(w, `<?xml version="1.0" encoding="utf-8"?><EnumerationResults><Blobs>%s</Blobs><NextMarker /></EnumerationResults>`, blobs)
+			return
+		}
+		_, _ = w.Write(valid)
+	}))
+	defer server.Close()
+
+	os.Setenv("DCTL_AZURERM_STORAGE_BLOB_ENDPOINT", server.URL)
+	os.Setenv("DCTL_AZURERM_STORAGE_SAS_TOKEN", "?sv=2020-10-02&sig=signature")
+	defer os.Unsetenv("DCTL_AZURERM_STORAGE_BLOB_ENDPOINT")
+	defer os.Unsetenv("DCTL_AZURERM_STORAGE_SAS_TOKEN")
+	defer os.Unsetenv("AZURE_STORAGE_BLOB_ENDPOINT")
+	defer os.Unsetenv("AZURE_STORAGE_SAS_TOKEN")
+
+	progress := &output.MockProgress{}
+	progress.On("Inc").Return().Times(stateCount)
+	alerter := alerter.NewAlerter()
+
+	stateConfig := config.SupplierConfig{
+		Key:     "tfstate",
+		Backend: backend.BackendKeyAzureRM,
+		Path:    "account/container/states/*.tfstate",
+	}
+	r, err := NewReader(stateConfig, terraform.NewProviderLibrary(), &backend.Options{}, progress, alerter, nil, nil)
+	assert.NoError(t, err)
+
+	_, err = r.Resources()
+	assert.NoError(t, err)
+	assert.Empty(t, alerter.Retrieve())
+	progress.AssertExpectations(t)
+	assert.Equal(t, "", os.Getenv("AZURE_STORAGE_BLOB_ENDPOINT"))
+}
@@ -0,0 +1,91 @@
+package aws
+
+import (
+	"strings"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/cloudskiff/driftctl/pkg/alerter"
+	"github.com/cloudskiff/driftctl/pkg/remote/alerts"
+	"github.com/cloudskiff/driftctl/pkg/remote/common"
+	remoteerror "github.com/cloudskiff/driftctl/pkg/remote/error"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const assumeRoleSessionName = "driftctl"
+
+// awsAccountType is used in alerts about accounts that cannot be scanned
+const awsAccountType = "aws_account"
+
+// awsAccount is an account scanned with the credentials of the session, or
+// with a role assumed from them
+type awsAccount struct {
+	id      string
+	roleARN string
+	session *session.Session
+}
+
+// providerAlias returns the alias of the provider configured for a region and
+// an assumed role, as the provider cannot switch roles once configured
+func providerAlias(region, roleARN string) string {
+	if roleARN == "" {
+		return region
+	}
+	return region + "|" + roleARN
+}
+
+func parseProviderAlias(alias string) (string, string) {
+	parts := strings.SplitN(alias, "|", 2)
+	if len(parts) == 1 {
+		return parts[0], ""
+	}
+	return parts[0], parts[1]
+}
+
+// Accounts returns the accounts to scan, the one of the session when no role
+// is given. Accounts whose role cannot be assumed are skipped with an alert.
+func (p *AWSTerraformProvider) Accounts(roleARNs []string, externalID string, alerter alerter.AlerterInterface) ([]awsAccount, error) {
+	if len(roleARNs) == 0 {
+		return []awsAccount{{session: p.session}}, nil
+	}
+
+	p.assumeRoleExternalID = externalID
+	accounts := make([]awsAccount, 0, len(roleARNs))
+	for _, roleARN := range roleARNs {
+		parsedARN, err := arn.Parse(roleARN)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid role ARN %s", roleARN)
+		}
+
+		creds := stscreds.NewCredentials(p.session, roleARN, func(provider *stscreds.AssumeRoleProvider) {
+			provider.RoleSessionName = assumeRoleSessionName
+			if externalID != "" {
+				provider.ExternalID = awssdk.String(externalID)
+			}
+		})
+		sess := p.session.Copy(&awssdk.Config{Credentials: creds})
+
+		if _, err := sts.New(sess).GetCallerIdentity(&sts.GetCallerIdentityInput{}); err != nil {
+			logrus.WithFields(logrus.Fields{
+				"role": roleARN,
+			}).Debugf("Unable to assume role: %+v", err)
+			alerts.SendEnumerationAlert(
+				common.RemoteAWSTerraform,
+				alerter,
+				remoteerror.NewResourceScanningError(err, awsAccountType, parsedARN.AccountID),
+			)
+			continue
+		}
+
+		accounts = append(accounts, awsAccount{
+			id:      parsedARN.AccountID,
+			roleARN: roleARN,
+			session: sess,
+		})
+	}
+	return accounts, nil
+}
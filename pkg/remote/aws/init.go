@@ -1,15 +1,19 @@
 package aws
 
 import (
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/cloudskiff/driftctl/pkg/alerter"
 	"github.com/cloudskiff/driftctl/pkg/output"
 	"github.com/cloudskiff/driftctl/pkg/remote/aws/client"
 	"github.com/cloudskiff/driftctl/pkg/remote/aws/repository"
 	"github.com/cloudskiff/driftctl/pkg/remote/cache"
 	"github.com/cloudskiff/driftctl/pkg/remote/common"
+	tf "github.com/cloudskiff/driftctl/pkg/remote/terraform"
 	"github.com/cloudskiff/driftctl/pkg/resource"
 	"github.com/cloudskiff/driftctl/pkg/resource/aws"
 	"github.com/cloudskiff/driftctl/pkg/terraform"
+	"github.com/sirupsen/logrus"
 )
 
 /**
@@ -23,7 +27,8 @@ func Init(version string, alerter *alerter.Alerter,
 	progress output.Progress,
 	resourceSchemaRepository *resource.SchemaRepository,
 	factory resource.ResourceFactory,
-	configDir string) error {
+	configDir string,
+	opts common.RemoteOptions) error {
 
 	provider, err := NewAWSTerraformProvider(version, progress, configDir)
 	if err != nil {
@@ -34,180 +39,65 @@ func Init(version string, alerter *alerter.Alerter,
 		return err
 	}
 
-	repositoryCache := cache.New(100)
-
-	s3Repository := repository.NewS3Repository(client.NewAWSClientFactory(provider.session), repositoryCache)
-	ec2repository := repository.NewEC2Repository(provider.session, repositoryCache)
-	route53repository := repository.NewRoute53Repository(provider.session, repositoryCache)
-	lambdaRepository := repository.NewLambdaRepository(provider.session, repositoryCache)
-	rdsRepository := repository.NewRDSRepository(provider.session, repositoryCache)
-	sqsRepository := repository.NewSQSRepository(provider.session, repositoryCache)
-	snsRepository := repository.NewSNSRepository(provider.session, repositoryCache)
-	cloudfrontRepository := repository.NewCloudfrontRepository(provider.session, repositoryCache)
-	dynamoDBRepository := repository.NewDynamoDBRepository(provider.session, repositoryCache)
-	ecrRepository := repository.NewECRRepository(provider.session, repositoryCache)
-	kmsRepository := repository.NewKMSRepository(provider.session, repositoryCache)
-	iamRepository := repository.NewIAMRepository(provider.session, repositoryCache)
-	cloudformationRepository := repository.NewCloudformationRepository(provider.session, repositoryCache)
-	apigatewayRepository := repository.NewApiGatewayRepository(provider.session, repositoryCache)
-	appAutoScalingRepository := repository.NewAppAutoScalingRepository(provider.session, repositoryCache)
+	regions, err := provider.Regions(opts.AWSRegions)
+	if err != nil {
+		return err
+	}
+	accounts, err := provider.Accounts(opts.AWSAssumeRoles, opts.AWSAssumeRoleExternalID, alerter)
+	if err != nil {
+		return err
+	}
+	logrus.WithFields(logrus.Fields{
+		"regions":  regions,
+		"accounts": len(accounts),
+	}).Debug("Scanning AWS regions")
 
 	deserializer := resource.NewDeserializer(factory)
 	providerLibrary.AddProvider(terraform.AWS, provider)
 
-	remoteLibrary.AddEnumerator(NewS3BucketEnumerator(s3Repository, factory, provider.Config, alerter))
-	remoteLibrary.AddDetailsFetcher(aws.AwsS3BucketResourceType, common.NewGenericDetailsFetcher(aws.AwsS3BucketResourceType, provider, deserializer))
-	remoteLibrary.AddEnumerator(NewS3BucketInventoryEnumerator(s3Repository, factory, provider.Config, alerter))
-	remoteLibrary.AddDetailsFetcher(aws.AwsS3BucketInventoryResourceType, common.NewGenericDetailsFetcher(aws.AwsS3BucketInventoryResourceType, provider, deserializer))
-	remoteLibrary.AddEnumerator(NewS3BucketNotificationEnumerator(s3Repository, factory, provider.Config, alerter))
-	remoteLibrary.AddDetailsFetcher(aws.AwsS3BucketNotificationResourceType, common.NewGenericDetailsFetcher(aws.AwsS3BucketNotificationResourceType, provider, deserializer))
-	remoteLibrary.AddEnumerator(NewS3BucketMetricsEnumerator(s3Repository, factory, provider.Config, alerter))
-	remoteLibrary.AddDetailsFetcher(aws.AwsS3BucketMetricResourceType, common.NewGenericDetailsFetcher(aws.AwsS3BucketMetricResourceType, provider, deserializer))
-	remoteLibrary.AddEnumerator(NewS3BucketPolicyEnumerator(s3Repository, factory, provider.Config, alerter))
-	remoteLibrary.AddDetailsFetcher(aws.AwsS3BucketPolicyResourceType, common.NewGenericDetailsFetcher(aws.AwsS3BucketPolicyResourceType, provider, deserializer))
-	remoteLibrary.AddEnumerator(NewS3BucketAnalyticEnumerator(s3Repository, factory, provider.Config, alerter))
-	remoteLibrary.AddDetailsFetcher(aws.AwsS3BucketAnalyticsConfigurationResourceType, common.NewGenericDetailsFetcher(aws.AwsS3BucketAnalyticsConfigurationResourceType, provider, deserializer))
-
-	remoteLibrary.AddEnumerator(NewEC2EbsVolumeEnumerator(ec2repository, factory))
-	remoteLibrary.AddDetailsFetcher(aws.AwsEbsVolumeResourceType, common.NewGenericDetailsFetcher(aws.AwsEbsVolumeResourceType, provider, deserializer))
-	remoteLibrary.AddEnumerator(NewEC2EbsSnapshotEnumerator(ec2repository, factory))
-	remoteLibrary.AddDetailsFetcher(aws.AwsEbsSnapshotResourceType, common.NewGenericDetailsFetcher(aws.AwsEbsSnapshotResourceType, provider, deserializer))
-	remoteLibrary.AddEnumerator(NewEC2EipEnumerator(ec2repository, factory))
-	remoteLibrary.AddDetailsFetcher(aws.AwsEipResourceType, common.NewGenericDetailsFetcher(aws.AwsEipResourceType, provider, deserializer))
-	remoteLibrary.AddEnumerator(NewEC2AmiEnumerator(ec2repository, factory))
-	remoteLibrary.AddDetailsFetcher(aws.AwsAmiResourceType, common.NewGenericDetailsFetcher(aws.AwsAmiResourceType, provider, deserializer))
-	remoteLibrary.AddEnumerator(NewEC2KeyPairEnumerator(ec2repository, factory))
-	remoteLibrary.AddDetailsFetcher(aws.AwsKeyPairResourceType, common.NewGenericDetailsFetcher(aws.AwsKeyPairResourceType, provider, deserializer))
-	remoteLibrary.AddEnumerator(NewEC2EipAssociationEnumerator(ec2repository, factory))
-	remoteLibrary.AddDetailsFetcher(aws.AwsEipAssociationResourceType, common.NewGenericDetailsFetcher(aws.AwsEipAssociationResourceType, provider, deserializer))
-	remoteLibrary.AddEnumerator(NewEC2InstanceEnumerator(ec2repository, factory))
-	remoteLibrary.AddDetailsFetcher(aws.AwsInstanceResourceType, common.NewGenericDetailsFetcher(aws.AwsInstanceResourceType, provider, deserializer))
-	remoteLibrary.AddEnumerator(NewEC2InternetGatewayEnumerator(ec2repository, factory))
-	remoteLibrary.AddDetailsFetcher(aws.AwsInternetGatewayResourceType, common.NewGenericDetailsFetcher(aws.AwsInternetGatewayResourceType, provider, deserializer))
-	remoteLibrary.AddEnumerator(NewVPCEnumerator(ec2repository, factory))
-	remoteLibrary.AddDetailsFetcher(aws.AwsVpcResourceType, common.NewGenericDetailsFetcher(aws.AwsVpcResourceType, provider, deserializer))
-	remoteLibrary.AddEnumerator(NewDefaultVPCEnumerator(ec2repository, factory))
-	remoteLibrary.AddDetailsFetcher(aws.AwsDefaultVpcResourceType, common.NewGenericDetailsFetcher(aws.AwsDefaultVpcResourceType, provider, deserializer))
-	remoteLibrary.AddEnumerator(NewEC2RouteTableEnumerator(ec2repository, factory))
-	remoteLibrary.AddDetailsFetcher(aws.AwsRouteTableResourceType, common.NewGenericDetailsFetcher(aws.AwsRouteTableResourceType, provider, deserializer))
-	remoteLibrary.AddEnumerator(NewEC2DefaultRouteTableEnumerator(ec2repository, factory))
-	remoteLibrary.AddDetailsFetcher(aws.AwsDefaultRouteTableResourceType, common.NewGenericDetailsFetcher(aws.AwsDefaultRouteTableResourceType, provider, deserializer))
-	remoteLibrary.AddEnumerator(NewEC2RouteTableAssociationEnumerator(ec2repository, factory))
-	remoteLibrary.AddDetailsFetcher(aws.AwsRouteTableAssociationResourceType, common.NewGenericDetailsFetcher(aws.AwsRouteTableAssociationResourceType, provider, deserializer))
-	remoteLibrary.AddEnumerator(NewEC2SubnetEnumerator(ec2repository, factory))
-	remoteLibrary.AddDetailsFetcher(aws.AwsSubnetResourceType, common.NewGenericDetailsFetcher(aws.AwsSubnetResourceType, provider, deserializer))
-	remoteLibrary.AddEnumerator(NewEC2DefaultSubnetEnumerator(ec2repository, factory))
-	remoteLibrary.AddDetailsFetcher(aws.AwsDefaultSubnetResourceType, common.NewGenericDetailsFetcher(aws.AwsDefaultSubnetResourceType, provider, deserializer))
-	remoteLibrary.AddEnumerator(NewVPCSecurityGroupEnumerator(ec2repository, factory))
-	remoteLibrary.AddDetailsFetcher(aws.AwsSecurityGroupResourceType, common.NewGenericDetailsFetcher(aws.AwsSecurityGroupResourceType, provider, deserializer))
-	remoteLibrary.AddEnumerator(NewVPCDefaultSecurityGroupEnumerator(ec2repository, factory))
-	remoteLibrary.AddDetailsFetcher(aws.AwsDefaultSecurityGroupResourceType, common.NewGenericDetailsFetcher(aws.AwsDefaultSecurityGroupResourceType, provider, deserializer))
-	remoteLibrary.AddEnumerator(NewEC2NatGatewayEnumerator(ec2repository, factory))
-	remoteLibrary.AddDetailsFetcher(aws.AwsNatGatewayResourceType, common.NewGenericDetailsFetcher(aws.AwsNatGatewayResourceType, provider, deserializer))
-	remoteLibrary.AddEnumerator(NewEC2NetworkACLEnumerator(ec2repository, factory))
-	remoteLibrary.AddDetailsFetcher(aws.AwsNetworkACLResourceType, common.NewGenericDetailsFetcher(aws.AwsNetworkACLResourceType, provider, deserializer))
-	remoteLibrary.AddEnumerator(NewEC2NetworkACLRuleEnumerator(ec2repository, factory))
-	remoteLibrary.AddDetailsFetcher(aws.AwsNetworkACLRuleResourceType, common.NewGenericDetailsFetcher(aws.AwsNetworkACLRuleResourceType, provider, deserializer))
-	remoteLibrary.AddEnumerator(NewEC2DefaultNetworkACLEnumerator(ec2repository, factory))
-	remoteLibrary.AddDetailsFetcher(aws.AwsDefaultNetworkACLResourceType, common.NewGenericDetailsFetcher(aws.AwsDefaultNetworkACLResourceType, provider, deserializer))
-	remoteLibrary.AddEnumerator(NewEC2RouteEnumerator(ec2repository, factory))
-	remoteLibrary.AddDetailsFetcher(aws.AwsRouteResourceType, common.NewGenericDetailsFetcher(aws.AwsRouteResourceType, provider, deserializer))
-	remoteLibrary.AddEnumerator(NewVPCSecurityGroupRuleEnumerator(ec2repository, factory))
-	remoteLibrary.AddDetailsFetcher(aws.AwsSecurityGroupRuleResourceType, common.NewGenericDetailsFetcher(aws.AwsSecurityGroupRuleResourceType, provider, deserializer))
-
-	remoteLibrary.AddEnumerator(NewKMSKeyEnumerator(kmsRepository, factory))
-	remoteLibrary.AddDetailsFetcher(aws.AwsKmsKeyResourceType, common.NewGenericDetailsFetcher(aws.AwsKmsKeyResourceType, provider, deserializer))
-	remoteLibrary.AddEnumerator(NewKMSAliasEnumerator(kmsRepository, factory))
-	remoteLibrary.AddDetailsFetcher(aws.AwsKmsAliasResourceType, common.NewGenericDetailsFetcher(aws.AwsKmsAliasResourceType, provider, deserializer))
-
-	remoteLibrary.AddEnumerator(NewRoute53HealthCheckEnumerator(route53repository, factory))
-	remoteLibrary.AddDetailsFetcher(aws.AwsRoute53HealthCheckResourceType, common.NewGenericDetailsFetcher(aws.AwsRoute53HealthCheckResourceType, provider, deserializer))
-	remoteLibrary.AddEnumerator(NewRoute53ZoneEnumerator(route53repository, factory))
-	remoteLibrary.AddDetailsFetcher(aws.AwsRoute53ZoneResourceType, common.NewGenericDetailsFetcher(aws.AwsRoute53ZoneResourceType, provider, deserializer))
-	remoteLibrary.AddEnumerator(NewRoute53RecordEnumerator(route53repository, factory))
-	remoteLibrary.AddDetailsFetcher(aws.AwsRoute53RecordResourceType, common.NewGenericDetailsFetcher(aws.AwsRoute53RecordResourceType, provider, deserializer))
-
-	remoteLibrary.AddEnumerator(NewCloudfrontDistributionEnumerator(cloudfrontRepository, factory))
-	remoteLibrary.AddDetailsFetcher(aws.AwsCloudfrontDistributionResourceType, common.NewGenericDetailsFetcher(aws.AwsCloudfrontDistributionResourceType, provider, deserializer))
-
-	remoteLibrary.AddEnumerator(NewRDSDBInstanceEnumerator(rdsRepository, factory))
-	remoteLibrary.AddDetailsFetcher(aws.AwsDbInstanceResourceType, common.NewGenericDetailsFetcher(aws.AwsDbInstanceResourceType, provider, deserializer))
-	remoteLibrary.AddEnumerator(NewRDSDBSubnetGroupEnumerator(rdsRepository, factory))
-	remoteLibrary.AddDetailsFetcher(aws.AwsDbSubnetGroupResourceType, common.NewGenericDetailsFetcher(aws.AwsDbSubnetGroupResourceType, provider, deserializer))
-
-	remoteLibrary.AddEnumerator(NewSQSQueueEnumerator(sqsRepository, factory))
-	remoteLibrary.AddDetailsFetcher(aws.AwsSqsQueueResourceType, NewSQSQueueDetailsFetcher(provider, deserializer))
-	remoteLibrary.AddEnumerator(NewSQSQueuePolicyEnumerator(sqsRepository, factory))
-	remoteLibrary.AddDetailsFetcher(aws.AwsSqsQueuePolicyResourceType, common.NewGenericDetailsFetcher(aws.AwsSqsQueuePolicyResourceType, provider, deserializer))
-
-	remoteLibrary.AddEnumerator(NewSNSTopicEnumerator(snsRepository, factory))
-	remoteLibrary.AddDetailsFetcher(aws.AwsSnsTopicResourceType, common.NewGenericDetailsFetcher(aws.AwsSnsTopicResourceType, provider, deserializer))
-	remoteLibrary.AddEnumerator(NewSNSTopicPolicyEnumerator(snsRepository, factory))
-	remoteLibrary.AddDetailsFetcher(aws.AwsSnsTopicPolicyResourceType, common.NewGenericDetailsFetcher(aws.AwsSnsTopicPolicyResourceType, provider, deserializer))
-	remoteLibrary.AddEnumerator(NewSNSTopicSubscriptionEnumerator(snsRepository, factory, alerter))
-	remoteLibrary.AddDetailsFetcher(aws.AwsSnsTopicSubscriptionResourceType, common.NewGenericDetailsFetcher(aws.AwsSnsTopicSubscriptionResourceType, provider, deserializer))
-
-	remoteLibrary.AddEnumerator(NewDynamoDBTableEnumerator(dynamoDBRepository, factory))
-	remoteLibrary.AddDetailsFetcher(aws.AwsDynamodbTableResourceType, common.NewGenericDetailsFetcher(aws.AwsDynamodbTableResourceType, provider, deserializer))
-
-	remoteLibrary.AddEnumerator(NewIamPolicyEnumerator(iamRepository, factory))
-	remoteLibrary.AddDetailsFetcher(aws.AwsIamPolicyResourceType, common.NewGenericDetailsFetcher(aws.AwsIamPolicyResourceType, provider, deserializer))
-
-	remoteLibrary.AddEnumerator(NewLambdaFunctionEnumerator(lambdaRepository, factory))
-	remoteLibrary.AddDetailsFetcher(aws.AwsLambdaFunctionResourceType, common.NewGenericDetailsFetcher(aws.AwsLambdaFunctionResourceType, provider, deserializer))
-	remoteLibrary.AddEnumerator(NewLambdaEventSourceMappingEnumerator(lambdaRepository, factory))
-	remoteLibrary.AddDetailsFetcher(aws.AwsLambdaEventSourceMappingResourceType, common.NewGenericDetailsFetcher(aws.AwsLambdaEventSourceMappingResourceType, provider, deserializer))
-
-	remoteLibrary.AddEnumerator(NewIamUserEnumerator(iamRepository, factory))
-	remoteLibrary.AddDetailsFetcher(aws.AwsIamUserResourceType, common.NewGenericDetailsFetcher(aws.AwsIamUserResourceType, provider, deserializer))
-	remoteLibrary.AddEnumerator(NewIamUserPolicyEnumerator(iamRepository, factory))
-	remoteLibrary.AddDetailsFetcher(aws.AwsIamUserPolicyResourceType, common.NewGenericDetailsFetcher(aws.AwsIamUserPolicyResourceType, provider, deserializer))
-	remoteLibrary.AddEnumerator(NewIamRoleEnumerator(iamRepository, factory))
-	remoteLibrary.AddDetailsFetcher(aws.AwsIamRoleResourceType, common.NewGenericDetailsFetcher(aws.AwsIamRoleResourceType, provider, deserializer))
-	remoteLibrary.AddEnumerator(NewIamAccessKeyEnumerator(iamRepository, factory))
-	remoteLibrary.AddDetailsFetcher(aws.AwsIamAccessKeyResourceType, common.NewGenericDetailsFetcher(aws.AwsIamAccessKeyResourceType, provider, deserializer))
-	remoteLibrary.AddEnumerator(NewIamRolePolicyAttachmentEnumerator(iamRepository, factory))
-	remoteLibrary.AddDetailsFetcher(aws.AwsIamRolePolicyAttachmentResourceType, common.NewGenericDetailsFetcher(aws.AwsIamRolePolicyAttachmentResourceType, provider, deserializer))
-	remoteLibrary.AddEnumerator(NewIamRolePolicyEnumerator(iamRepository, factory))
-	remoteLibrary.AddDetailsFetcher(aws.AwsIamRolePolicyResourceType, common.NewGenericDetailsFetcher(aws.AwsIamRolePolicyResourceType, provider, deserializer))
-	remoteLibrary.AddEnumerator(NewIamUserPolicyAttachmentEnumerator(iamRepository, factory))
-	remoteLibrary.AddDetailsFetcher(aws.AwsIamUserPolicyAttachmentResourceType, common.NewGenericDetailsFetcher(aws.AwsIamUserPolicyAttachmentResourceType, provider, deserializer))
-
-	remoteLibrary.AddEnumerator(NewECRRepositoryEnumerator(ecrRepository, factory))
-	remoteLibrary.AddDetailsFetcher(aws.AwsEcrRepositoryResourceType, common.NewGenericDetailsFetcher(aws.AwsEcrRepositoryResourceType, provider, deserializer))
-
-	remoteLibrary.AddEnumerator(NewRDSClusterEnumerator(rdsRepository, factory))
-	remoteLibrary.AddDetailsFetcher(aws.AwsRDSClusterResourceType, common.NewGenericDetailsFetcher(aws.AwsRDSClusterResourceType, provider, deserializer))
-
-	remoteLibrary.AddEnumerator(NewCloudformationStackEnumerator(cloudformationRepository, factory))
-	remoteLibrary.AddDetailsFetcher(aws.AwsCloudformationStackResourceType, common.NewGenericDetailsFetcher(aws.AwsCloudformationStackResourceType, provider, deserializer))
-
-	remoteLibrary.AddEnumerator(NewApiGatewayRestApiEnumerator(apigatewayRepository, factory))
-	remoteLibrary.AddEnumerator(NewApiGatewayAccountEnumerator(apigatewayRepository, factory))
-	remoteLibrary.AddEnumerator(NewApiGatewayApiKeyEnumerator(apigatewayRepository, factory))
-	remoteLibrary.AddEnumerator(NewApiGatewayAuthorizerEnumerator(apigatewayRepository, factory))
-	remoteLibrary.AddEnumerator(NewApiGatewayStageEnumerator(apigatewayRepository, factory))
-	remoteLibrary.AddEnumerator(NewApiGatewayResourceEnumerator(apigatewayRepository, factory))
-	remoteLibrary.AddEnumerator(NewApiGatewayDomainNameEnumerator(apigatewayRepository, factory))
-	remoteLibrary.AddEnumerator(NewApiGatewayVpcLinkEnumerator(apigatewayRepository, factory))
-	remoteLibrary.AddEnumerator(NewApiGatewayRequestValidatorEnumerator(apigatewayRepository, factory))
-	remoteLibrary.AddEnumerator(NewApiGatewayRestApiPolicyEnumerator(apigatewayRepository, factory))
-	remoteLibrary.AddEnumerator(NewApiGatewayBasePathMappingEnumerator(apigatewayRepository, factory))
-	remoteLibrary.AddEnumerator(NewApiGatewayMethodEnumerator(apigatewayRepository, factory))
-	remoteLibrary.AddEnumerator(NewApiGatewayModelEnumerator(apigatewayRepository, factory))
-	remoteLibrary.AddEnumerator(NewApiGatewayMethodResponseEnumerator(apigatewayRepository, factory))
-	remoteLibrary.AddEnumerator(NewApiGatewayGatewayResponseEnumerator(apigatewayRepository, factory))
-	remoteLibrary.AddEnumerator(NewApiGatewayMethodSettingsEnumerator(apigatewayRepository, factory))
-	remoteLibrary.AddEnumerator(NewApiGatewayIntegrationEnumerator(apigatewayRepository, factory))
-	remoteLibrary.AddEnumerator(NewApiGatewayIntegrationResponseEnumerator(apigatewayRepository, factory))
-
-	remoteLibrary.AddEnumerator(NewAppAutoscalingTargetEnumerator(appAutoScalingRepository, factory))
-	remoteLibrary.AddDetailsFetcher(aws.AwsAppAutoscalingTargetResourceType, common.NewGenericDetailsFetcher(aws.AwsAppAutoscalingTargetResourceType, provider, deserializer))
-
-	remoteLibrary.AddEnumerator(NewAppAutoscalingPolicyEnumerator(appAutoScalingRepository, factory))
-	remoteLibrary.AddDetailsFetcher(aws.AwsAppAutoscalingPolicyResourceType, common.NewGenericDetailsFetcher(aws.AwsAppAutoscalingPolicyResourceType, provider, deserializer))
-
-	remoteLibrary.AddEnumerator(NewAppAutoscalingScheduledActionEnumerator(appAutoScalingRepository, factory))
+	// Resources are scoped to their account when roles are assumed, and to
+	// their region when several regions are scanned
+	scopeAccounts := len(opts.AWSAssumeRoles) > 0
+	scopeRegions := len(regions) > 1
+
+	for _, account := range accounts {
+		accountScope := map[string]string{}
+		if scopeAccounts {
+			accountScope[common.ScopeAWSAccount] = account.id
+		}
+
+		// Cache keys are not scoped by account, each account has its own cache
+		repositoryCache := cache.New(100)
+
+		// Buckets are listed once for all regions, then filtered by location by
+		// enumerators of each region
+		s3Repository := repository.NewS3Repository(client.NewAWSClientFactory(account.session), repositoryCache)
+
+		for _, region := range regions {
+			scope := make(map[string]string, len(accountScope)+1)
+			for k, v := range accountScope {
+				scope[k] = v
+			}
+			if scopeRegions {
+				scope[common.ScopeRegion] = region
+			}
+			var reader terraform.ResourceReader = provider
+			if len(scope) > 0 {
+				reader = newScopedReader(provider, region, account.roleARN)
+			}
+			sess := account.session.Copy(&awssdk.Config{Region: awssdk.String(region)})
+			providerConfig := provider.Config
+			providerConfig.DefaultAlias = region
+			initRegion(common.NewScopedLibrary(remoteLibrary, scope), sess, s3Repository, providerConfig, reader, alerter, factory, deserializer)
+		}
+
+		// Global services are enumerated once per account
+		var reader terraform.ResourceReader = provider
+		if scopeAccounts {
+			reader = newScopedReader(provider, provider.Config.DefaultAlias, account.roleARN)
+		}
+		initGlobal(common.NewScopedLibrary(remoteLibrary, accountScope), account.session, repositoryCache, reader, factory, deserializer)
+	}
 
 	err = resourceSchemaRepository.Init(terraform.AWS, provider.Version(), provider.Schema())
 	if err != nil {
@@ -217,3 +107,193 @@ func Init(version string, alerter *alerter.Alerter,
 
 	return nil
 }
+
+func initRegion(library *common.ScopedLibrary,
+	sess *session.Session,
+	s3Repository repository.S3Repository,
+	providerConfig tf.TerraformProviderConfig,
+	reader terraform.ResourceReader,
+	alerter *alerter.Alerter,
+	factory resource.ResourceFactory,
+	deserializer *resource.Deserializer) {
+
+	// Cache keys are not scoped by region, each region has its own cache
+	repositoryCache := cache.New(100)
+
+	ec2repository := repository.NewEC2Repository(sess, repositoryCache)
+	lambdaRepository := repository.NewLambdaRepository(sess, repositoryCache)
+	rdsRepository := repository.NewRDSRepository(sess, repositoryCache)
+	sqsRepository := repository.NewSQSRepository(sess, repositoryCache)
+	snsRepository := repository.NewSNSRepository(sess, repositoryCache)
+	dynamoDBRepository := repository.NewDynamoDBRepository(sess, repositoryCache)
+	ecrRepository := repository.NewECRRepository(sess, repositoryCache)
+	kmsRepository := repository.NewKMSRepository(sess, repositoryCache)
+	cloudformationRepository := repository.NewCloudformationRepository(sess, repositoryCache)
+	apigatewayRepository := repository.NewApiGatewayRepository(sess, repositoryCache)
+	appAutoScalingRepository := repository.NewAppAutoScalingRepository(sess, repositoryCache)
+
+	library.AddEnumerator(NewS3BucketEnumerator(s3Repository, factory, providerConfig, alerter))
+	library.AddDetailsFetcher(aws.AwsS3BucketResourceType, common.NewGenericDetailsFetcher(aws.AwsS3BucketResourceType, reader, deserializer))
+	library.AddEnumerator(NewS3BucketInventoryEnumerator(s3Repository, factory, providerConfig, alerter))
+	library.AddDetailsFetcher(aws.AwsS3BucketInventoryResourceType, common.NewGenericDetailsFetcher(aws.AwsS3BucketInventoryResourceType, reader, deserializer))
+	library.AddEnumerator(NewS3BucketNotificationEnumerator(s3Repository, factory, providerConfig, alerter))
+	library.AddDetailsFetcher(aws.AwsS3BucketNotificationResourceType, common.NewGenericDetailsFetcher(aws.AwsS3BucketNotificationResourceType, reader, deserializer))
+	library.AddEnumerator(NewS3BucketMetricsEnumerator(s3Repository, factory, providerConfig, alerter))
+	library.AddDetailsFetcher(aws.AwsS3BucketMetricResourceType, common.NewGenericDetailsFetcher(aws.AwsS3BucketMetricResourceType, reader, deserializer))
+	library.AddEnumerator(NewS3BucketPolicyEnumerator(s3Repository, factory, providerConfig, alerter))
+	library.AddDetailsFetcher(aws.AwsS3BucketPolicyResourceType, common.NewGenericDetailsFetcher(aws.AwsS3BucketPolicyResourceType, reader, deserializer))
+	library.AddEnumerator(NewS3BucketAnalyticEnumerator(s3Repository, factory, providerConfig, alerter))
+	library.AddDetailsFetcher(aws.AwsS3BucketAnalyticsConfigurationResourceType, common.NewGenericDetailsFetcher(aws.AwsS3BucketAnalyticsConfigurationResourceType, reader, deserializer))
+
+	library.AddEnumerator(NewEC2EbsVolumeEnumerator(ec2repository, factory))
+	library.AddDetailsFetcher(aws.AwsEbsVolumeResourceType, common.NewGenericDetailsFetcher(aws.AwsEbsVolumeResourceType, reader, deserializer))
+	library.AddEnumerator(NewEC2EbsSnapshotEnumerator(ec2repository, factory))
+	library.AddDetailsFetcher(aws.AwsEbsSnapshotResourceType, common.NewGenericDetailsFetcher(aws.AwsEbsSnapshotResourceType, reader, deserializer))
+	library.AddEnumerator(NewEC2EipEnumerator(ec2repository, factory))
+	library.AddDetailsFetcher(aws.AwsEipResourceType, common.NewGenericDetailsFetcher(aws.AwsEipResourceType, reader, deserializer))
+	library.AddEnumerator(NewEC2AmiEnumerator(ec2repository, factory))
+	library.AddDetailsFetcher(aws.AwsAmiResourceType, common.NewGenericDetailsFetcher(aws.AwsAmiResourceType, reader, deserializer))
+	library.AddEnumerator(NewEC2KeyPairEnumerator(ec2repository, factory))
+	library.AddDetailsFetcher(aws.AwsKeyPairResourceType, common.NewGenericDetailsFetcher(aws.AwsKeyPairResourceType, reader, deserializer))
+	library.AddEnumerator(NewEC2EipAssociationEnumerator(ec2repository, factory))
+	library.AddDetailsFetcher(aws.AwsEipAssociationResourceType, common.NewGenericDetailsFetcher(aws.AwsEipAssociationResourceType, reader, deserializer))
+	library.AddEnumerator(NewEC2InstanceEnumerator(ec2repository, factory))
+	library.AddDetailsFetcher(aws.AwsInstanceResourceType, common.NewGenericDetailsFetcher(aws.AwsInstanceResourceType, reader, deserializer))
+	library.AddEnumerator(NewEC2InternetGatewayEnumerator(ec2repository, factory))
+	library.AddDetailsFetcher(aws.AwsInternetGatewayResourceType, common.NewGenericDetailsFetcher(aws.AwsInternetGatewayResourceType, reader, deserializer))
+	library.AddEnumerator(NewVPCEnumerator(ec2repository, factory))
+	library.AddDetailsFetcher(aws.AwsVpcResourceType, common.NewGenericDetailsFetcher(aws.AwsVpcResourceType, reader, deserializer))
+	library.AddEnumerator(NewDefaultVPCEnumerator(ec2repository, factory))
+	library.AddDetailsFetcher(aws.AwsDefaultVpcResourceType, common.NewGenericDetailsFetcher(aws.AwsDefaultVpcResourceType, reader, deserializer))
+	library.AddEnumerator(NewEC2RouteTableEnumerator(ec2repository, factory))
+	library.AddDetailsFetcher(aws.AwsRouteTableResourceType, common.NewGenericDetailsFetcher(aws.AwsRouteTableResourceType, reader, deserializer))
+	library.AddEnumerator(NewEC2DefaultRouteTableEnumerator(ec2repository, factory))
+	library.AddDetailsFetcher(aws.AwsDefaultRouteTableResourceType, common.NewGenericDetailsFetcher(aws.AwsDefaultRouteTableResourceType, reader, deserializer))
+	library.AddEnumerator(NewEC2RouteTableAssociationEnumerator(ec2repository, factory))
+	library.AddDetailsFetcher(aws.AwsRouteTableAssociationResourceType, common.NewGenericDetailsFetcher(aws.AwsRouteTableAssociationResourceType, reader, deserializer))
+	library.AddEnumerator(NewEC2SubnetEnumerator(ec2repository, factory))
+	library.AddDetailsFetcher(aws.AwsSubnetResourceType, common.NewGenericDetailsFetcher(aws.AwsSubnetResourceType, reader, deserializer))
+	library.AddEnumerator(NewEC2DefaultSubnetEnumerator(ec2repository, factory))
+	library.AddDetailsFetcher(aws.AwsDefaultSubnetResourceType, common.NewGenericDetailsFetcher(aws.AwsDefaultSubnetResourceType, reader, deserializer))
+	library.AddEnumerator(NewVPCSecurityGroupEnumerator(ec2repository, factory))
+	library.AddDetailsFetcher(aws.AwsSecurityGroupResourceType, common.NewGenericDetailsFetcher(aws.AwsSecurityGroupResourceType, reader, deserializer))
+	library.AddEnumerator(NewVPCDefaultSecurityGroupEnumerator(ec2repository, factory))
+	library.AddDetailsFetcher(aws.AwsDefaultSecurityGroupResourceType, common.NewGenericDetailsFetcher(aws.AwsDefaultSecurityGroupResourceType, reader, deserializer))
+	library.AddEnumerator(NewEC2NatGatewayEnumerator(ec2repository, factory))
+	library.AddDetailsFetcher(aws.AwsNatGatewayResourceType, common.NewGenericDetailsFetcher(aws.AwsNatGatewayResourceType, reader, deserializer))
+	library.AddEnumerator(NewEC2NetworkACLEnumerator(ec2repository, factory))
+	library.AddDetailsFetcher(aws.AwsNetworkACLResourceType, common.NewGenericDetailsFetcher(aws.AwsNetworkACLResourceType, reader, deserializer))
+	library.AddEnumerator(NewEC2NetworkACLRuleEnumerator(ec2repository, factory))
+	library.AddDetailsFetcher(aws.AwsNetworkACLRuleResourceType, common.NewGenericDetailsFetcher(aws.AwsNetworkACLRuleResourceType, reader, deserializer))
+	library.AddEnumerator(NewEC2DefaultNetworkACLEnumerator(ec2repository, factory))
+	library.AddDetailsFetcher(aws.AwsDefaultNetworkACLResourceType, common.NewGenericDetailsFetcher(aws.AwsDefaultNetworkACLResourceType, reader, deserializer))
+	library.AddEnumerator(NewEC2RouteEnumerator(ec2repository, factory))
+	library.AddDetailsFetcher(aws.AwsRouteResourceType, common.NewGenericDetailsFetcher(aws.AwsRouteResourceType, reader, deserializer))
+	library.AddEnumerator(NewVPCSecurityGroupRuleEnumerator(ec2repository, factory))
+	library.AddDetailsFetcher(aws.AwsSecurityGroupRuleResourceType, common.NewGenericDetailsFetcher(aws.AwsSecurityGroupRuleResourceType, reader, deserializer))
+
+	library.AddEnumerator(NewKMSKeyEnumerator(kmsRepository, factory))
+	library.AddDetailsFetcher(aws.AwsKmsKeyResourceType, common.NewGenericDetailsFetcher(aws.AwsKmsKeyResourceType, reader, deserializer))
+	library.AddEnumerator(NewKMSAliasEnumerator(kmsRepository, factory))
+	library.AddDetailsFetcher(aws.AwsKmsAliasResourceType, common.NewGenericDetailsFetcher(aws.AwsKmsAliasResourceType, reader, deserializer))
+
+	library.AddEnumerator(NewRDSDBInstanceEnumerator(rdsRepository, factory))
+	library.AddDetailsFetcher(aws.AwsDbInstanceResourceType, common.NewGenericDetailsFetcher(aws.AwsDbInstanceResourceType, reader, deserializer))
+	library.AddEnumerator(NewRDSDBSubnetGroupEnumerator(rdsRepository, factory))
+	library.AddDetailsFetcher(aws.AwsDbSubnetGroupResourceType, common.NewGenericDetailsFetcher(aws.AwsDbSubnetGroupResourceType, reader, deserializer))
+
+	library.AddEnumerator(NewSQSQueueEnumerator(sqsRepository, factory))
+	library.AddDetailsFetcher(aws.AwsSqsQueueResourceType, NewSQSQueueDetailsFetcher(reader, deserializer))
+	library.AddEnumerator(NewSQSQueuePolicyEnumerator(sqsRepository, factory))
+	library.AddDetailsFetcher(aws.AwsSqsQueuePolicyResourceType, common.NewGenericDetailsFetcher(aws.AwsSqsQueuePolicyResourceType, reader, deserializer))
+
+	library.AddEnumerator(NewSNSTopicEnumerator(snsRepository, factory))
+	library.AddDetailsFetcher(aws.AwsSnsTopicResourceType, common.NewGenericDetailsFetcher(aws.AwsSnsTopicResourceType, reader, deserializer))
+	library.AddEnumerator(NewSNSTopicPolicyEnumerator(snsRepository, factory))
+	library.AddDetailsFetcher(aws.AwsSnsTopicPolicyResourceType, common.NewGenericDetailsFetcher(aws.AwsSnsTopicPolicyResourceType, reader, deserializer))
+	library.AddEnumerator(NewSNSTopicSubscriptionEnumerator(snsRepository, factory, alerter))
+	library.AddDetailsFetcher(aws.AwsSnsTopicSubscriptionResourceType, common.NewGenericDetailsFetcher(aws.AwsSnsTopicSubscriptionResourceType, reader, deserializer))
+
+	library.AddEnumerator(NewDynamoDBTableEnumerator(dynamoDBRepository, factory))
+	library.AddDetailsFetcher(aws.AwsDynamodbTableResourceType, common.NewGenericDetailsFetcher(aws.AwsDynamodbTableResourceType, reader, deserializer))
+
+	library.AddEnumerator(NewLambdaFunctionEnumerator(lambdaRepository, factory))
+	library.AddDetailsFetcher(aws.AwsLambdaFunctionResourceType, common.NewGenericDetailsFetcher(aws.AwsLambdaFunctionResourceType, reader, deserializer))
+	library.AddEnumerator(NewLambdaEventSourceMappingEnumerator(lambdaRepository, factory))
+	library.AddDetailsFetcher(aws.AwsLambdaEventSourceMappingResourceType, common.NewGenericDetailsFetcher(aws.AwsLambdaEventSourceMappingResourceType, reader, deserializer))
+
+	library.AddEnumerator(NewECRRepositoryEnumerator(ecrRepository, factory))
+	library.AddDetailsFetcher(aws.AwsEcrRepositoryResourceType, common.NewGenericDetailsFetcher(aws.AwsEcrRepositoryResourceType, reader, deserializer))
+
+	library.AddEnumerator(NewRDSClusterEnumerator(rdsRepository, factory))
+	library.AddDetailsFetcher(aws.AwsRDSClusterResourceType, common.NewGenericDetailsFetcher(aws.AwsRDSClusterResourceType, reader, deserializer))
+
+	library.AddEnumerator(NewCloudformationStackEnumerator(cloudformationRepository, factory))
+	library.AddDetailsFetcher(aws.AwsCloudformationStackResourceType, common.NewGenericDetailsFetcher(aws.AwsCloudformationStackResourceType, reader, deserializer))
+
+	library.AddEnumerator(NewApiGatewayRestApiEnumerator(apigatewayRepository, factory))
+	library.AddEnumerator(NewApiGatewayAccountEnumerator(apigatewayRepository, factory))
+	library.AddEnumerator(NewApiGatewayApiKeyEnumerator(apigatewayRepository, factory))
+	library.AddEnumerator(NewApiGatewayAuthorizerEnumerator(apigatewayRepository, factory))
+	library.AddEnumerator(NewApiGatewayStageEnumerator(apigatewayRepository, factory))
+	library.AddEnumerator(NewApiGatewayResourceEnumerator(apigatewayRepository, factory))
+	library.AddEnumerator(NewApiGatewayDomainNameEnumerator(apigatewayRepository, factory))
+	library.AddEnumerator(NewApiGatewayVpcLinkEnumerator(apigatewayRepository, factory))
+	library.AddEnumerator(NewApiGatewayRequestValidatorEnumerator(apigatewayRepository, factory))
+	library.AddEnumerator(NewApiGatewayRestApiPolicyEnumerator(apigatewayRepository, factory))
+	library.AddEnumerator(NewApiGatewayBasePathMappingEnumerator(apigatewayRepository, factory))
+	library.AddEnumerator(NewApiGatewayMethodEnumerator(apigatewayRepository, factory))
+	library.AddEnumerator(NewApiGatewayModelEnumerator(apigatewayRepository, factory))
+	library.AddEnumerator(NewApiGatewayMethodResponseEnumerator(apigatewayRepository, factory))
+	library.AddEnumerator(NewApiGatewayGatewayResponseEnumerator(apigatewayRepository, factory))
+	library.AddEnumerator(NewApiGatewayMethodSettingsEnumerator(apigatewayRepository, factory))
+	library.AddEnumerator(NewApiGatewayIntegrationEnumerator(apigatewayRepository, factory))
+	library.AddEnumerator(NewApiGatewayIntegrationResponseEnumerator(apigatewayRepository, factory))
+
+	library.AddEnumerator(NewAppAutoscalingTargetEnumerator(appAutoScalingRepository, factory))
+	library.AddDetailsFetcher(aws.AwsAppAutoscalingTargetResourceType, common.NewGenericDetailsFetcher(aws.AwsAppAutoscalingTargetResourceType, reader, deserializer))
+
+	library.AddEnumerator(NewAppAutoscalingPolicyEnumerator(appAutoScalingRepository, factory))
+	library.AddDetailsFetcher(aws.AwsAppAutoscalingPolicyResourceType, common.NewGenericDetailsFetcher(aws.AwsAppAutoscalingPolicyResourceType, reader, deserializer))
+
+	library.AddEnumerator(NewAppAutoscalingScheduledActionEnumerator(appAutoScalingRepository, factory))
+}
+
+func initGlobal(library *common.ScopedLibrary,
+	sess *session.Session,
+	repositoryCache cache.Cache,
+	reader terraform.ResourceReader,
+	factory resource.ResourceFactory,
+	deserializer *resource.Deserializer) {
+
+	route53repository := repository.NewRoute53Repository(sess, repositoryCache)
+	cloudfrontRepository := repository.NewCloudfrontRepository(sess, repositoryCache)
+	iamRepository := repository.NewIAMRepository(sess, repositoryCache)
+
+	library.AddEnumerator(NewRoute53HealthCheckEnumerator(route53repository, factory))
+	library.AddDetailsFetcher(aws.AwsRoute53HealthCheckResourceType, common.NewGenericDetailsFetcher(aws.AwsRoute53HealthCheckResourceType, reader, deserializer))
+	library.AddEnumerator(NewRoute53ZoneEnumerator(route53repository, factory))
+	library.AddDetailsFetcher(aws.AwsRoute53ZoneResourceType, common.NewGenericDetailsFetcher(aws.AwsRoute53ZoneResourceType, reader, deserializer))
+	library.AddEnumerator(NewRoute53RecordEnumerator(route53repository, factory))
+	library.AddDetailsFetcher(aws.AwsRoute53RecordResourceType, common.NewGenericDetailsFetcher(aws.AwsRoute53RecordResourceType, reader, deserializer))
+
+	library.AddEnumerator(NewCloudfrontDistributionEnumerator(cloudfrontRepository, factory))
+	library.AddDetailsFetcher(aws.AwsCloudfrontDistributionResourceType, common.NewGenericDetailsFetcher(aws.AwsCloudfrontDistributionResourceType, reader, deserializer))
+
+	library.AddEnumerator(NewIamPolicyEnumerator(iamRepository, factory))
+	library.AddDetailsFetcher(aws.AwsIamPolicyResourceType, common.NewGenericDetailsFetcher(aws.AwsIamPolicyResourceType, reader, deserializer))
+	library.AddEnumerator(NewIamUserEnumerator(iamRepository, factory))
+	library.AddDetailsFetcher(aws.AwsIamUserResourceType, common.NewGenericDetailsFetcher(aws.AwsIamUserResourceType, reader, deserializer))
+	library.AddEnumerator(NewIamUserPolicyEnumerator(iamRepository, factory))
+	library.AddDetailsFetcher(aws.AwsIamUserPolicyResourceType, common.NewGenericDetailsFetcher(aws.AwsIamUserPolicyResourceType, reader, deserializer))
+	library.AddEnumerator(NewIamRoleEnumerator(iamRepository, factory))
+	library.AddDetailsFetcher(aws.AwsIamRoleResourceType, common.NewGenericDetailsFetcher(aws.AwsIamRoleResourceType, reader, deserializer))
+	library.AddEnumerator(NewIamAccessKeyEnumerator(iamRepository, factory))
+	library.AddDetailsFetcher(aws.AwsIamAccessKeyResourceType, common.NewGenericDetailsFetcher(aws.AwsIamAccessKeyResourceType, reader, deserializer))
+	library.AddEnumerator(NewIamRolePolicyAttachmentEnumerator(iamRepository, factory))
+	library.AddDetailsFetcher(aws.AwsIamRolePolicyAttachmentResourceType, common.NewGenericDetailsFetcher(aws.AwsIamRolePolicyAttachmentResourceType, reader, deserializer))
+	library.AddEnumerator(NewIamRolePolicyEnumerator(iamRepository, factory))
+	library.AddDetailsFetcher(aws.AwsIamRolePolicyResourceType, common.NewGenericDetailsFetcher(aws.AwsIamRolePolicyResourceType, reader, deserializer))
+	library.AddEnumerator(NewIamUserPolicyAttachmentEnumerator(iamRepository, factory))
+	library.AddDetailsFetcher(aws.AwsIamUserPolicyAttachmentResourceType, common.NewGenericDetailsFetcher(aws.AwsIamUserPolicyAttachmentResourceType, reader, deserializer))
+}
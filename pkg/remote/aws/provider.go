@@ -1,8 +1,11 @@
 package aws
 
 import (
+	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/cloudskiff/driftctl/pkg/output"
+	"github.com/cloudskiff/driftctl/pkg/remote/common"
 	"github.com/cloudskiff/driftctl/pkg/remote/terraform"
 	tf "github.com/cloudskiff/driftctl/pkg/terraform"
 )
@@ -16,10 +19,7 @@ type awsConfig struct {
 	Region        string `cty:"region"`
 	MaxRetries    int
 
-	AssumeRoleARN         string
-	AssumeRoleExternalID  string
-	AssumeRoleSessionName string
-	AssumeRolePolicy      string
+	AssumeRole []awsAssumeRoleConfig `cty:"assume_role"`
 
 	AllowedAccountIds   []string
 	ForbiddenAccountIds []string
@@ -36,11 +36,19 @@ type awsConfig struct {
 	S3ForcePathStyle        bool
 }
 
+type awsAssumeRoleConfig struct {
+	RoleARN     string  `cty:"role_arn"`
+	ExternalID  *string `cty:"external_id"`
+	SessionName *string `cty:"session_name"`
+}
+
 type AWSTerraformProvider struct {
 	*terraform.TerraformProvider
 	session *session.Session
 	name    string
 	version string
+	// assumeRoleExternalID is given when assuming roles of scanned accounts
+	assumeRoleExternalID string
 }
 
 func NewAWSTerraformProvider(version string, progress output.Progress, configDir string) (*AWSTerraformProvider, error) {
@@ -66,10 +74,22 @@ func NewAWSTerraformProvider(version string, progress output.Progress, configDir
 		Name:         p.name,
 		DefaultAlias: *p.session.Config.Region,
 		GetProviderConfig: func(alias string) interface{} {
-			return awsConfig{
-				Region:     alias,
+			region, roleARN := parseProviderAlias(alias)
+			config := awsConfig{
+				Region:     region,
 				MaxRetries: 10, // TODO make this configurable
 			}
+			if roleARN != "" {
+				assumeRole := awsAssumeRoleConfig{
+					RoleARN:     roleARN,
+					SessionName: awssdk.String(assumeRoleSessionName),
+				}
+				if p.assumeRoleExternalID != "" {
+					assumeRole.ExternalID = awssdk.String(p.assumeRoleExternalID)
+				}
+				config.AssumeRole = []awsAssumeRoleConfig{assumeRole}
+			}
+			return config
 		},
 	}, progress)
 	if err != nil {
@@ -86,3 +106,24 @@ func (a *AWSTerraformProvider) Name() string {
 func (p *AWSTerraformProvider) Version() string {
 	return p.version
 }
+
+// Regions returns the regions to scan, the region of the session when none is
+// requested or every region enabled for the account when all are requested
+func (p *AWSTerraformProvider) Regions(requested []string) ([]string, error) {
+	if len(requested) == 0 {
+		return []string{*p.session.Config.Region}, nil
+	}
+	if len(requested) > 1 || requested[0] != common.AWSAllRegions {
+		return requested, nil
+	}
+
+	output, err := ec2.New(p.session).DescribeRegions(&ec2.DescribeRegionsInput{})
+	if err != nil {
+		return nil, err
+	}
+	regions := make([]string, 0, len(output.Regions))
+	for _, region := range output.Regions {
+		regions = append(regions, awssdk.StringValue(region.RegionName))
+	}
+	return regions, nil
+}
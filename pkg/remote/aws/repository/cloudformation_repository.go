@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"fmt"
+
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/cloudformation"
 	"github.com/aws/aws-sdk-go/service/cloudformation/cloudformationiface"
@@ -9,6 +11,7 @@ import (
 
 type CloudformationRepository interface {
 	ListAllStacks() ([]*cloudformation.Stack, error)
+	ListStackResources(stackName string) ([]*cloudformation.StackResourceSummary, error)
 }
 
 type cloudformationRepository struct {
@@ -45,3 +48,29 @@ func (r *cloudformationRepository) ListAllStacks() ([]*cloudformation.Stack, err
 	r.cache.Put("cloudformationListAllStacks", stacks)
 	return stacks, nil
 }
+
+func (r *cloudformationRepository) ListStackResources(stackName string) ([]*cloudformation.StackResourceSummary, error) {
+	cacheKey := fmt.Sprintf("cloudformationListStackResources_%s", stackName)
+	if v := r.cache.Get(cacheKey); v != nil {
+		return v.([]*cloudformation.StackResourceSummary), nil
+	}
+
+	var resources []*cloudformation.StackResourceSummary
+	input := cloudformation.ListStackResourcesInput{
+		StackName: &stackName,
+	}
+	err := r.client.ListStackResourcesPages(&input,
+		func(resp *cloudformation.ListStackResourcesOutput, lastPage bool) bool {
+			if resp.StackResourceSummaries != nil {
+				resources = append(resources, resp.StackResourceSummaries...)
+			}
+			return !lastPage
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	r.cache.Put(cacheKey, resources)
+	return resources, nil
+}
@@ -84,3 +84,70 @@ func Test_cloudformationRepository_ListAllStacks(t *testing.T) {
 		})
 	}
 }
+
+func Test_cloudformationRepository_ListStackResources(t *testing.T) {
+	resources := []*cloudformation.StackResourceSummary{
+		{LogicalResourceId: aws.String("Bucket"), PhysicalResourceId: aws.String("my-bucket"), ResourceType: aws.String("AWS::S3::Bucket")},
+		{LogicalResourceId: aws.String("Queue"), PhysicalResourceId: aws.String("https://sqs.us-east-1.amazonaws.com/123456789012/my-queue"), ResourceType: aws.String("AWS::SQS::Queue")},
+		{LogicalResourceId: aws.String("Role"), PhysicalResourceId: aws.String("my-role"), ResourceType: aws.String("AWS::IAM::Role")},
+	}
+
+	tests := []struct {
+		name    string
+		mocks   func(client *awstest.MockFakeCloudformation, store *cache.MockCache)
+		want    []*cloudformation.StackResourceSummary
+		wantErr error
+	}{
+		{
+			name: "list stack resources",
+			mocks: func(client *awstest.MockFakeCloudformation, store *cache.MockCache) {
+				client.On("ListStackResourcesPages",
+					&cloudformation.ListStackResourcesInput{StackName: aws.String("my-stack")},
+					mock.MatchedBy(func(callback func(res *cloudformation.ListStackResourcesOutput, lastPage bool) bool) bool {
+						callback(&cloudformation.ListStackResourcesOutput{
+							StackResourceSummaries: resources[:2],
+						}, false)
+						callback(&cloudformation.ListStackResourcesOutput{
+							StackResourceSummaries: resources[2:],
+						}, true)
+						return true
+					})).Return(nil).Once()
+
+				store.On("Get", "cloudformationListStackResources_my-stack").Return(nil).Times(1)
+				store.On("Put", "cloudformationListStackResources_my-stack", resources).Return(false).Times(1)
+			},
+			want: resources,
+		},
+		{
+			name: "should hit cache",
+			mocks: func(client *awstest.MockFakeCloudformation, store *cache.MockCache) {
+				store.On("Get", "cloudformationListStackResources_my-stack").Return(resources).Times(1)
+			},
+			want: resources,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &cache.MockCache{}
+			client := &awstest.MockFakeCloudformation{}
+			tt.mocks(client, store)
+			r := &cloudformationRepository{
+				client: client,
+				cache:  store,
+			}
+			got, err := r.ListStackResources("my-stack")
+			assert.Equal(t, tt.wantErr, err)
+
+			changelog, err := diff.Diff(got, tt.want)
+			assert.Nil(t, err)
+			if len(changelog) > 0 {
+				for _, change := range changelog {
+					t.Errorf("%s: %s -> %s", strings.Join(change.Path, "."), change.From, change.To)
+				}
+				t.Fail()
+			}
+			store.AssertExpectations(t)
+			client.AssertExpectations(t)
+		})
+	}
+}
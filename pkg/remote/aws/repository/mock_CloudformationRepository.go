@@ -34,3 +34,26 @@ func (_m *MockCloudformationRepository) ListAllStacks() ([]*cloudformation.Stack
 
 	return r0, r1
 }
+
+// ListStackResources provides a mock function with given fields: stackName
+func (_m *MockCloudformationRepository) ListStackResources(stackName string) ([]*cloudformation.StackResourceSummary, error) {
+	ret := _m.Called(stackName)
+
+	var r0 []*cloudformation.StackResourceSummary
+	if rf, ok := ret.Get(0).(func(string) []*cloudformation.StackResourceSummary); ok {
+		r0 = rf(stackName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*cloudformation.StackResourceSummary)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(stackName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,32 @@
+package aws
+
+import (
+	"github.com/cloudskiff/driftctl/pkg/terraform"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// scopedReader reads resources through the provider alias of a region and of
+// an assumed role. Resources may set their own region alias, like S3 buckets.
+type scopedReader struct {
+	reader  terraform.ResourceReader
+	region  string
+	roleARN string
+}
+
+func newScopedReader(reader terraform.ResourceReader, region, roleARN string) *scopedReader {
+	return &scopedReader{reader, region, roleARN}
+}
+
+func (r *scopedReader) ReadResource(args terraform.ReadResourceArgs) (*cty.Value, error) {
+	attributes := make(map[string]string, len(args.Attributes)+1)
+	for k, v := range args.Attributes {
+		attributes[k] = v
+	}
+	region := attributes["alias"]
+	if region == "" {
+		region = r.region
+	}
+	attributes["alias"] = providerAlias(region, r.roleARN)
+	args.Attributes = attributes
+	return r.reader.ReadResource(args)
+}
@@ -0,0 +1,69 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/cloudskiff/driftctl/pkg/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/zclconf/go-cty/cty"
+)
+
+type fakeResourceReader struct {
+	args []terraform.ReadResourceArgs
+}
+
+func (r *fakeResourceReader) ReadResource(args terraform.ReadResourceArgs) (*cty.Value, error) {
+	r.args = append(r.args, args)
+	val := cty.NullVal(cty.DynamicPseudoType)
+	return &val, nil
+}
+
+func TestScopedReader_ReadResource(t *testing.T) {
+	fake := &fakeResourceReader{}
+	reader := newScopedReader(fake, "eu-west-3", "")
+
+	attributes := map[string]string{"name": "test"}
+	_, err := reader.ReadResource(terraform.ReadResourceArgs{Ty: "aws_vpc", ID: "vpc-1", Attributes: attributes})
+	assert.NoError(t, err)
+	_, err = reader.ReadResource(terraform.ReadResourceArgs{Ty: "aws_s3_bucket", ID: "bucket", Attributes: map[string]string{"alias": "us-east-1"}})
+	assert.NoError(t, err)
+	_, err = reader.ReadResource(terraform.ReadResourceArgs{Ty: "aws_kms_key", ID: "key"})
+	assert.NoError(t, err)
+
+	assert.Equal(t, map[string]string{"name": "test", "alias": "eu-west-3"}, fake.args[0].Attributes)
+	assert.Equal(t, map[string]string{"alias": "us-east-1"}, fake.args[1].Attributes)
+	assert.Equal(t, map[string]string{"alias": "eu-west-3"}, fake.args[2].Attributes)
+	assert.Equal(t, map[string]string{"name": "test"}, attributes)
+}
+
+func TestScopedReader_ReadResourceWithRole(t *testing.T) {
+	fake := &fakeResourceReader{}
+	reader := newScopedReader(fake, "eu-west-3", "arn:aws:iam::123456789012:role/driftctl")
+
+	_, err := reader.ReadResource(terraform.ReadResourceArgs{Ty: "aws_vpc", ID: "vpc-1"})
+	assert.NoError(t, err)
+	_, err = reader.ReadResource(terraform.ReadResourceArgs{Ty: "aws_s3_bucket", ID: "bucket", Attributes: map[string]string{"alias": "us-east-1"}})
+	assert.NoError(t, err)
+
+	assert.Equal(t, map[string]string{"alias": "eu-west-3|arn:aws:iam::123456789012:role/driftctl"}, fake.args[0].Attributes)
+	assert.Equal(t, map[string]string{"alias": "us-east-1|arn:aws:iam::123456789012:role/driftctl"}, fake.args[1].Attributes)
+}
+
+func TestProviderAlias(t *testing.T) {
+	tests := []struct {
+		region  string
+		roleARN string
+		alias   string
+	}{
+		{region: "us-east-1", alias: "us-east-1"},
+		{region: "us-east-1", roleARN: "arn:aws:iam::123456789012:role/driftctl", alias: "us-east-1|arn:aws:iam::123456789012:role/driftctl"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.alias, func(t *testing.T) {
+			assert.Equal(t, tt.alias, providerAlias(tt.region, tt.roleARN))
+			region, roleARN := parseProviderAlias(tt.alias)
+			assert.Equal(t, tt.region, region)
+			assert.Equal(t, tt.roleARN, roleARN)
+		})
+	}
+}
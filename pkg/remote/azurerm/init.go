@@ -5,6 +5,7 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 	"github.com/cloudskiff/driftctl/pkg/alerter"
 	"github.com/cloudskiff/driftctl/pkg/output"
+	azurermcommon "github.com/cloudskiff/driftctl/pkg/remote/azurerm/common"
 	"github.com/cloudskiff/driftctl/pkg/remote/azurerm/repository"
 	"github.com/cloudskiff/driftctl/pkg/remote/cache"
 	"github.com/cloudskiff/driftctl/pkg/remote/common"
@@ -21,7 +22,8 @@ func Init(
 	progress output.Progress,
 	resourceSchemaRepository *resource.SchemaRepository,
 	factory resource.ResourceFactory,
-	configDir string) error {
+	configDir string,
+	opts common.RemoteOptions) error {
 
 	provider, err := NewAzureTerraformProvider(version, progress, configDir)
 	if err != nil {
@@ -39,47 +41,28 @@ func Init(
 	}
 	con := arm.NewDefaultConnection(cred, nil)
 
-	c := cache.New(100)
-
-	storageAccountRepo := repository.NewStorageRepository(con, providerConfig, c)
-	networkRepo := repository.NewNetworkRepository(con, providerConfig, c)
-	resourcesRepo := repository.NewResourcesRepository(con, providerConfig, c)
-	containerRegistryRepo := repository.NewContainerRegistryRepository(con, providerConfig, c)
-	postgresqlRepo := repository.NewPostgresqlRepository(con, providerConfig, c)
-	privateDNSRepo := repository.NewPrivateDNSRepository(con, providerConfig, c)
-	computeRepo := repository.NewComputeRepository(con, providerConfig, c)
-
 	providerLibrary.AddProvider(terraform.AZURE, provider)
 	deserializer := resource.NewDeserializer(factory)
 
-	remoteLibrary.AddEnumerator(NewAzurermStorageAccountEnumerator(storageAccountRepo, factory))
-	remoteLibrary.AddEnumerator(NewAzurermStorageContainerEnumerator(storageAccountRepo, factory))
-	remoteLibrary.AddEnumerator(NewAzurermVirtualNetworkEnumerator(networkRepo, factory))
-	remoteLibrary.AddEnumerator(NewAzurermRouteTableEnumerator(networkRepo, factory))
-	remoteLibrary.AddEnumerator(NewAzurermRouteEnumerator(networkRepo, factory))
-	remoteLibrary.AddEnumerator(NewAzurermResourceGroupEnumerator(resourcesRepo, factory))
-	remoteLibrary.AddEnumerator(NewAzurermSubnetEnumerator(networkRepo, factory))
-	remoteLibrary.AddEnumerator(NewAzurermContainerRegistryEnumerator(containerRegistryRepo, factory))
-	remoteLibrary.AddEnumerator(NewAzurermFirewallsEnumerator(networkRepo, factory))
-	remoteLibrary.AddEnumerator(NewAzurermPostgresqlServerEnumerator(postgresqlRepo, factory))
-	remoteLibrary.AddEnumerator(NewAzurermPublicIPEnumerator(networkRepo, factory))
-	remoteLibrary.AddEnumerator(NewAzurermPostgresqlDatabaseEnumerator(postgresqlRepo, factory))
-	remoteLibrary.AddEnumerator(NewAzurermNetworkSecurityGroupEnumerator(networkRepo, factory))
-	remoteLibrary.AddDetailsFetcher(azurerm.AzureNetworkSecurityGroupResourceType, common.NewGenericDetailsFetcher(azurerm.AzureNetworkSecurityGroupResourceType, provider, deserializer))
-	remoteLibrary.AddEnumerator(NewAzurermLoadBalancerEnumerator(networkRepo, factory))
-	remoteLibrary.AddEnumerator(NewAzurermPrivateDNSZoneEnumerator(privateDNSRepo, factory))
-	remoteLibrary.AddDetailsFetcher(azurerm.AzurePrivateDNSZoneResourceType, common.NewGenericDetailsFetcher(azurerm.AzurePrivateDNSZoneResourceType, provider, deserializer))
-	remoteLibrary.AddEnumerator(NewAzurermPrivateDNSARecordEnumerator(privateDNSRepo, factory))
-	remoteLibrary.AddDetailsFetcher(azurerm.AzurePrivateDNSARecordResourceType, common.NewGenericDetailsFetcher(azurerm.AzurePrivateDNSARecordResourceType, provider, deserializer))
-	remoteLibrary.AddEnumerator(NewAzurermPrivateDNSAAAARecordEnumerator(privateDNSRepo, factory))
-	remoteLibrary.AddDetailsFetcher(azurerm.AzurePrivateDNSAAAARecordResourceType, common.NewGenericDetailsFetcher(azurerm.AzurePrivateDNSAAAARecordResourceType, provider, deserializer))
-	remoteLibrary.AddEnumerator(NewAzurermPrivateDNSCNameRecordEnumerator(privateDNSRepo, factory))
-	remoteLibrary.AddDetailsFetcher(azurerm.AzurePrivateDNSCNameRecordResourceType, common.NewGenericDetailsFetcher(azurerm.AzurePrivateDNSCNameRecordResourceType, provider, deserializer))
-	remoteLibrary.AddEnumerator(NewAzurermPrivateDNSPTRRecordEnumerator(privateDNSRepo, factory))
-	remoteLibrary.AddDetailsFetcher(azurerm.AzurePrivateDNSPTRRecordResourceType, common.NewGenericDetailsFetcher(azurerm.AzurePrivateDNSPTRRecordResourceType, provider, deserializer))
-	remoteLibrary.AddEnumerator(NewAzurermImageEnumerator(computeRepo, factory))
-	remoteLibrary.AddEnumerator(NewAzurermSSHPublicKeyEnumerator(computeRepo, factory))
-	remoteLibrary.AddDetailsFetcher(azurerm.AzureSSHPublicKeyResourceType, common.NewGenericDetailsFetcher(azurerm.AzureSSHPublicKeyResourceType, provider, deserializer))
+	subscriptionsRepo := repository.NewSubscriptionsRepository(con, cache.New(1))
+	subscriptionIDs, err := listSubscriptions(subscriptionsRepo, providerConfig.SubscriptionID, opts.AzureSubscriptions)
+	if err != nil {
+		return err
+	}
+
+	for _, subscriptionID := range subscriptionIDs {
+		// Resources are scoped with their subscription whenever subscriptions
+		// are given, even if a single one is scanned
+		var scope map[string]string
+		var reader terraform.ResourceReader = provider
+		if len(opts.AzureSubscriptions) > 0 {
+			scope = map[string]string{common.ScopeAzureSubscription: subscriptionID}
+			reader = newSubscriptionReader(provider, subscriptionID)
+		}
+		subscriptionConfig := providerConfig
+		subscriptionConfig.SubscriptionID = subscriptionID
+		initSubscription(common.NewScopedLibrary(remoteLibrary, scope), con, subscriptionConfig, cache.New(100), reader, factory, deserializer)
+	}
 
 	err = resourceSchemaRepository.Init(terraform.AZURE, provider.Version(), provider.Schema())
 	if err != nil {
@@ -89,3 +72,50 @@ func Init(
 
 	return nil
 }
+
+// initSubscription registers enumerators and details fetchers of a
+// subscription, each subscription has its own repositories
+func initSubscription(library *common.ScopedLibrary,
+	con *arm.Connection,
+	providerConfig azurermcommon.AzureProviderConfig,
+	c cache.Cache,
+	reader terraform.ResourceReader,
+	factory resource.ResourceFactory,
+	deserializer *resource.Deserializer) {
+	storageAccountRepo := repository.NewStorageRepository(con, providerConfig, c)
+	networkRepo := repository.NewNetworkRepository(con, providerConfig, c)
+	resourcesRepo := repository.NewResourcesRepository(con, providerConfig, c)
+	containerRegistryRepo := repository.NewContainerRegistryRepository(con, providerConfig, c)
+	postgresqlRepo := repository.NewPostgresqlRepository(con, providerConfig, c)
+	privateDNSRepo := repository.NewPrivateDNSRepository(con, providerConfig, c)
+	computeRepo := repository.NewComputeRepository(con, providerConfig, c)
+
+	library.AddEnumerator(NewAzurermStorageAccountEnumerator(storageAccountRepo, factory))
+	library.AddEnumerator(NewAzurermStorageContainerEnumerator(storageAccountRepo, factory))
+	library.AddEnumerator(NewAzurermVirtualNetworkEnumerator(networkRepo, factory))
+	library.AddEnumerator(NewAzurermRouteTableEnumerator(networkRepo, factory))
+	library.AddEnumerator(NewAzurermRouteEnumerator(networkRepo, factory))
+	library.AddEnumerator(NewAzurermResourceGroupEnumerator(resourcesRepo, factory))
+	library.AddEnumerator(NewAzurermSubnetEnumerator(networkRepo, factory))
+	library.AddEnumerator(NewAzurermContainerRegistryEnumerator(containerRegistryRepo, factory))
+	library.AddEnumerator(NewAzurermFirewallsEnumerator(networkRepo, factory))
+	library.AddEnumerator(NewAzurermPostgresqlServerEnumerator(postgresqlRepo, factory))
+	library.AddEnumerator(NewAzurermPublicIPEnumerator(networkRepo, factory))
+	library.AddEnumerator(NewAzurermPostgresqlDatabaseEnumerator(postgresqlRepo, factory))
+	library.AddEnumerator(NewAzurermNetworkSecurityGroupEnumerator(networkRepo, factory))
+	library.AddDetailsFetcher(azurerm.AzureNetworkSecurityGroupResourceType, common.NewGenericDetailsFetcher(azurerm.AzureNetworkSecurityGroupResourceType, reader, deserializer))
+	library.AddEnumerator(NewAzurermLoadBalancerEnumerator(networkRepo, factory))
+	library.AddEnumerator(NewAzurermPrivateDNSZoneEnumerator(privateDNSRepo, factory))
+	library.AddDetailsFetcher(azurerm.AzurePrivateDNSZoneResourceType, common.NewGenericDetailsFetcher(azurerm.AzurePrivateDNSZoneResourceType, reader, deserializer))
+	library.AddEnumerator(NewAzurermPrivateDNSARecordEnumerator(privateDNSRepo, factory))
+	library.AddDetailsFetcher(azurerm.AzurePrivateDNSARecordResourceType, common.NewGenericDetailsFetcher(azurerm.AzurePrivateDNSARecordResourceType, reader, deserializer))
+	library.AddEnumerator(NewAzurermPrivateDNSAAAARecordEnumerator(privateDNSRepo, factory))
+	library.AddDetailsFetcher(azurerm.AzurePrivateDNSAAAARecordResourceType, common.NewGenericDetailsFetcher(azurerm.AzurePrivateDNSAAAARecordResourceType, reader, deserializer))
+	library.AddEnumerator(NewAzurermPrivateDNSCNameRecordEnumerator(privateDNSRepo, factory))
+	library.AddDetailsFetcher(azurerm.AzurePrivateDNSCNameRecordResourceType, common.NewGenericDetailsFetcher(azurerm.AzurePrivateDNSCNameRecordResourceType, reader, deserializer))
+	library.AddEnumerator(NewAzurermPrivateDNSPTRRecordEnumerator(privateDNSRepo, factory))
+	library.AddDetailsFetcher(azurerm.AzurePrivateDNSPTRRecordResourceType, common.NewGenericDetailsFetcher(azurerm.AzurePrivateDNSPTRRecordResourceType, reader, deserializer))
+	library.AddEnumerator(NewAzurermImageEnumerator(computeRepo, factory))
+	library.AddEnumerator(NewAzurermSSHPublicKeyEnumerator(computeRepo, factory))
+	library.AddDetailsFetcher(azurerm.AzureSSHPublicKeyResourceType, common.NewGenericDetailsFetcher(azurerm.AzureSSHPublicKeyResourceType, reader, deserializer))
+}
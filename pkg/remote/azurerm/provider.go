@@ -36,8 +36,12 @@ func NewAzureTerraformProvider(version string, progress output.Progress, configD
 
 	tfProvider, err := terraform.NewTerraformProvider(installer, terraform.TerraformProviderConfig{
 		Name: p.name,
-		GetProviderConfig: func(_ string) interface{} {
+		GetProviderConfig: func(alias string) interface{} {
 			c := p.GetConfig()
+			// Aliases are subscription IDs when several subscriptions are scanned
+			if alias != "" {
+				c.SubscriptionID = alias
+			}
 			return map[string]interface{}{
 				"subscription_id":            c.SubscriptionID,
 				"tenant_id":                  c.TenantID,
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/cloudskiff/driftctl/pkg/remote/cache"
+)
+
+const subscriptionStateEnabled = "Enabled"
+
+// Subscription is a subscription visible to the principal. There is no
+// subscriptions client in the version of the SDK used, so subscriptions are
+// read from the ARM API directly.
+type Subscription struct {
+	SubscriptionID *string `json:"subscriptionId,omitempty"`
+	DisplayName    *string `json:"displayName,omitempty"`
+	State          *string `json:"state,omitempty"`
+}
+
+type subscriptionListResult struct {
+	Value    []*Subscription `json:"value,omitempty"`
+	NextLink *string         `json:"nextLink,omitempty"`
+}
+
+type SubscriptionsRepository interface {
+	ListAllSubscriptions() ([]*Subscription, error)
+}
+
+type subscriptionsClient interface {
+	List(ctx context.Context, nextLink string) (subscriptionListResult, error)
+}
+
+type subscriptionsClientImpl struct {
+	ep string
+	pl runtime.Pipeline
+}
+
+// List reads a page of subscriptions, the first one when nextLink is empty
+func (c subscriptionsClientImpl) List(ctx context.Context, nextLink string) (subscriptionListResult, error) {
+	endpoint := nextLink
+	if endpoint == "" {
+		endpoint = runtime.JoinPaths(c.ep, "/subscriptions")
+	}
+	req, err := runtime.NewRequest(ctx, http.MethodGet, endpoint)
+	if err != nil {
+		return subscriptionListResult{}, err
+	}
+	if nextLink == "" {
+		reqQP := req.Raw().URL.Query()
+		reqQP.Set("api-version", "2020-01-01")
+		req.Raw().URL.RawQuery = reqQP.Encode()
+	}
+	req.Raw().Header.Set("Accept", "application/json")
+
+	resp, err := c.pl.Do(req)
+	if err != nil {
+		return subscriptionListResult{}, err
+	}
+	if !runtime.HasStatusCode(resp, http.StatusOK) {
+		body, _ := runtime.Payload(resp)
+		return subscriptionListResult{}, runtime.NewResponseError(fmt.Errorf("unable to list subscriptions: %s", string(body)), resp)
+	}
+	result := subscriptionListResult{}
+	if err := runtime.UnmarshalAsJSON(resp, &result); err != nil {
+		return subscriptionListResult{}, err
+	}
+	return result, nil
+}
+
+type subscriptionsRepository struct {
+	client subscriptionsClient
+	cache  cache.Cache
+}
+
+func NewSubscriptionsRepository(con *arm.Connection, cache cache.Cache) *subscriptionsRepository {
+	return &subscriptionsRepository{
+		&subscriptionsClientImpl{ep: con.Endpoint(), pl: con.NewPipeline("driftctl", "")},
+		cache,
+	}
+}
+
+// ListAllSubscriptions returns enabled subscriptions visible to the principal
+func (s *subscriptionsRepository) ListAllSubscriptions() ([]*Subscription, error) {
+	cacheKey := "subscriptionsListAllSubscriptions"
+	if v := s.cache.Get(cacheKey); v != nil {
+		return v.([]*Subscription), nil
+	}
+
+	results := make([]*Subscription, 0)
+	nextLink := ""
+	for {
+		page, err := s.client.List(context.Background(), nextLink)
+		if err != nil {
+			return nil, err
+		}
+		for _, subscription := range page.Value {
+			if subscription.State != nil && *subscription.State != subscriptionStateEnabled {
+				continue
+			}
+			results = append(results, subscription)
+		}
+		if page.NextLink == nil || *page.NextLink == "" {
+			break
+		}
+		nextLink = *page.NextLink
+	}
+
+	s.cache.Put(cacheKey, results)
+
+	return results, nil
+}
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/cloudskiff/driftctl/pkg/remote/cache"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_Subscriptions_ListAllSubscriptions(t *testing.T) {
+	expectedResults := []*Subscription{
+		{
+			SubscriptionID: to.StringPtr("008b5f48-1b66-4d92-a6b6-d215b4c9b473"),
+			DisplayName:    to.StringPtr("dev"),
+			State:          to.StringPtr("Enabled"),
+		},
+		{
+			SubscriptionID: to.StringPtr("7bfb2c5c-7308-46ed-8ae4-fffa356eb406"),
+			DisplayName:    to.StringPtr("prod"),
+			State:          to.StringPtr("Enabled"),
+		},
+	}
+
+	testcases := []struct {
+		name     string
+		mocks    func(*mockSubscriptionsClient, *cache.MockCache)
+		expected []*Subscription
+		wantErr  string
+	}{
+		{
+			name: "should return enabled subscriptions of every page",
+			mocks: func(client *mockSubscriptionsClient, mockCache *cache.MockCache) {
+				client.On("List", mock.Anything, "").Return(subscriptionListResult{
+					Value: []*Subscription{
+						expectedResults[0],
+						{
+							SubscriptionID: to.StringPtr("0b0a0b0a-0000-0000-0000-000000000000"),
+							DisplayName:    to.StringPtr("legacy"),
+							State:          to.StringPtr("Disabled"),
+						},
+					},
+					NextLink: to.StringPtr("https://management.azure.com/subscriptions?page=2"),
+				}, nil).Times(1)
+				client.On("List", mock.Anything, "https://management.azure.com/subscriptions?page=2").Return(subscriptionListResult{
+					Value: []*Subscription{expectedResults[1]},
+				}, nil).Times(1)
+
+				mockCache.On("Get", "subscriptionsListAllSubscriptions").Return(nil).Times(1)
+				mockCache.On("Put", "subscriptionsListAllSubscriptions", expectedResults).Return(true).Times(1)
+			},
+			expected: expectedResults,
+		},
+		{
+			name: "should hit cache and return subscriptions",
+			mocks: func(client *mockSubscriptionsClient, mockCache *cache.MockCache) {
+				mockCache.On("Get", "subscriptionsListAllSubscriptions").Return(expectedResults).Times(1)
+			},
+			expected: expectedResults,
+		},
+		{
+			name: "should return remote error",
+			mocks: func(client *mockSubscriptionsClient, mockCache *cache.MockCache) {
+				client.On("List", mock.Anything, "").Return(subscriptionListResult{}, errors.New("remote error")).Times(1)
+
+				mockCache.On("Get", "subscriptionsListAllSubscriptions").Return(nil).Times(1)
+			},
+			wantErr: "remote error",
+		},
+	}
+
+	for _, tt := range testcases {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := &mockSubscriptionsClient{}
+			mockCache := &cache.MockCache{}
+
+			tt.mocks(fakeClient, mockCache)
+
+			s := &subscriptionsRepository{
+				client: fakeClient,
+				cache:  mockCache,
+			}
+			got, err := s.ListAllSubscriptions()
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.Nil(t, err)
+			}
+
+			fakeClient.AssertExpectations(t)
+			mockCache.AssertExpectations(t)
+
+			if tt.wantErr == "" {
+				assert.Equal(t, tt.expected, got)
+			}
+		})
+	}
+}
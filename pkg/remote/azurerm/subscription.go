@@ -0,0 +1,56 @@
+package azurerm
+
+import (
+	"github.com/cloudskiff/driftctl/pkg/remote/azurerm/repository"
+	"github.com/cloudskiff/driftctl/pkg/remote/common"
+	"github.com/cloudskiff/driftctl/pkg/terraform"
+	"github.com/pkg/errors"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// listSubscriptions returns IDs of subscriptions to scan, the default one when
+// none is given
+func listSubscriptions(repo repository.SubscriptionsRepository, defaultSubscriptionID string, subscriptionIDs []string) ([]string, error) {
+	if len(subscriptionIDs) == 0 {
+		return []string{defaultSubscriptionID}, nil
+	}
+	if len(subscriptionIDs) > 1 || subscriptionIDs[0] != common.AzureAllSubscriptions {
+		return subscriptionIDs, nil
+	}
+
+	subscriptions, err := repo.ListAllSubscriptions()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to list subscriptions to scan")
+	}
+	results := make([]string, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		if subscription.SubscriptionID != nil && *subscription.SubscriptionID != "" {
+			results = append(results, *subscription.SubscriptionID)
+		}
+	}
+	if len(results) == 0 {
+		return nil, errors.New("no subscription found to scan")
+	}
+	return results, nil
+}
+
+// subscriptionReader reads resources through the provider alias of a
+// subscription, as the provider is configured for a single subscription
+type subscriptionReader struct {
+	reader         terraform.ResourceReader
+	subscriptionID string
+}
+
+func newSubscriptionReader(reader terraform.ResourceReader, subscriptionID string) *subscriptionReader {
+	return &subscriptionReader{reader, subscriptionID}
+}
+
+func (r *subscriptionReader) ReadResource(args terraform.ReadResourceArgs) (*cty.Value, error) {
+	attributes := make(map[string]string, len(args.Attributes)+1)
+	for k, v := range args.Attributes {
+		attributes[k] = v
+	}
+	attributes["alias"] = r.subscriptionID
+	args.Attributes = attributes
+	return r.reader.ReadResource(args)
+}
@@ -0,0 +1,98 @@
+package azurerm
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/cloudskiff/driftctl/pkg/remote/azurerm/repository"
+	"github.com/cloudskiff/driftctl/pkg/terraform"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/zclconf/go-cty/cty"
+)
+
+type fakeResourceReader struct {
+	args []terraform.ReadResourceArgs
+}
+
+func (r *fakeResourceReader) ReadResource(args terraform.ReadResourceArgs) (*cty.Value, error) {
+	r.args = append(r.args, args)
+	val := cty.NullVal(cty.DynamicPseudoType)
+	return &val, nil
+}
+
+func TestListSubscriptions(t *testing.T) {
+	tests := []struct {
+		name            string
+		subscriptionIDs []string
+		mocks           func(*repository.MockSubscriptionsRepository)
+		want            []string
+		wantErr         string
+	}{
+		{
+			name: "default subscription",
+			want: []string{"default"},
+		},
+		{
+			name:            "given subscriptions",
+			subscriptionIDs: []string{"sub-1", "sub-2"},
+			want:            []string{"sub-1", "sub-2"},
+		},
+		{
+			name:            "all subscriptions",
+			subscriptionIDs: []string{"all"},
+			mocks: func(repo *repository.MockSubscriptionsRepository) {
+				repo.On("ListAllSubscriptions").Return([]*repository.Subscription{
+					{SubscriptionID: to.StringPtr("sub-1")},
+					{SubscriptionID: to.StringPtr("sub-2")},
+				}, nil)
+			},
+			want: []string{"sub-1", "sub-2"},
+		},
+		{
+			name:            "no subscription visible",
+			subscriptionIDs: []string{"all"},
+			mocks: func(repo *repository.MockSubscriptionsRepository) {
+				repo.On("ListAllSubscriptions").Return([]*repository.Subscription{}, nil)
+			},
+			wantErr: "no subscription found to scan",
+		},
+		{
+			name:            "cannot list subscriptions",
+			subscriptionIDs: []string{"all"},
+			mocks: func(repo *repository.MockSubscriptionsRepository) {
+				repo.On("ListAllSubscriptions").Return(nil, errors.New("remote error"))
+			},
+			wantErr: "unable to list subscriptions to scan: remote error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &repository.MockSubscriptionsRepository{}
+			if tt.mocks != nil {
+				tt.mocks(repo)
+			}
+
+			got, err := listSubscriptions(repo, "default", tt.subscriptionIDs)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSubscriptionReader_ReadResource(t *testing.T) {
+	fake := &fakeResourceReader{}
+	reader := newSubscriptionReader(fake, "sub-1")
+
+	attributes := map[string]string{"name": "test"}
+	_, err := reader.ReadResource(terraform.ReadResourceArgs{Ty: "azurerm_ssh_public_key", ID: "key", Attributes: attributes})
+	assert.NoError(t, err)
+
+	assert.Equal(t, map[string]string{"name": "test", "alias": "sub-1"}, fake.args[0].Attributes)
+	assert.Equal(t, map[string]string{"name": "test"}, attributes)
+}
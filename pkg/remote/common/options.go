@@ -0,0 +1,29 @@
+package common
+
+// AWSAllRegions scans every region enabled for the AWS account
+const AWSAllRegions = "all"
+
+// AzureAllSubscriptions scans every subscription visible to the principal
+const AzureAllSubscriptions = "all"
+
+// GCPScopePrefixes are the kinds of scopes Google Cloud resources can be
+// scanned in
+var GCPScopePrefixes = []string{"projects/", "folders/", "organizations/"}
+
+// RemoteOptions are settings given to remotes on initialization
+type RemoteOptions struct {
+	// AWSRegions are the regions scanned by the AWS remote, the region of the
+	// session is scanned when empty and enabled regions when set to AWSAllRegions
+	AWSRegions []string
+	// AWSAssumeRoles are ARNs of roles assumed to scan several AWS accounts
+	AWSAssumeRoles []string
+	// AWSAssumeRoleExternalID is given when assuming AWS roles, if any
+	AWSAssumeRoleExternalID string
+	// GCPScopes are the projects, folders or organizations scanned by the
+	// Google remote, the project of the environment is scanned when empty
+	GCPScopes []string
+	// AzureSubscriptions are the subscriptions scanned by the Azure remote, the
+	// subscription of the environment is scanned when empty and subscriptions
+	// visible to the principal when set to AzureAllSubscriptions
+	AzureSubscriptions []string
+}
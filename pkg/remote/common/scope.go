@@ -0,0 +1,128 @@
+package common
+
+import (
+	"sort"
+	"strings"
+
+	remoteerror "github.com/cloudskiff/driftctl/pkg/remote/error"
+	"github.com/cloudskiff/driftctl/pkg/resource"
+	"github.com/pkg/errors"
+)
+
+// Scopes of resources, set when several of them are scanned
+const (
+	// ScopeRegion is the region a resource was enumerated in
+	ScopeRegion = "region"
+	// ScopeAWSAccount is the ID of the AWS account of a resource
+	ScopeAWSAccount = "account_id"
+	// ScopeAzureSubscription is the ID of the Azure subscription of a resource
+	ScopeAzureSubscription = "subscription_id"
+	// ScopeGCPProject is the ID of the Google Cloud project of a resource
+	ScopeGCPProject = "project"
+)
+
+// ScopedLibrary registers enumerators and details fetchers of a scope of a
+// remote, like an account and a region, when several scopes are scanned.
+// Enumerated resources are scoped with the given values, which are then used
+// to pick the details fetcher of the right scope.
+type ScopedLibrary struct {
+	library *RemoteLibrary
+	scope   map[string]string
+}
+
+// NewScopedLibrary returns a library registering directly in the given one
+// when the scope is empty
+func NewScopedLibrary(library *RemoteLibrary, scope map[string]string) *ScopedLibrary {
+	return &ScopedLibrary{
+		library: library,
+		scope:   scope,
+	}
+}
+
+func (l *ScopedLibrary) AddEnumerator(enumerator Enumerator) {
+	if len(l.scope) > 0 {
+		enumerator = &scopedEnumerator{enumerator, l.scope}
+	}
+	l.library.AddEnumerator(enumerator)
+}
+
+func (l *ScopedLibrary) AddDetailsFetcher(ty resource.ResourceType, detailsFetcher DetailsFetcher) {
+	if len(l.scope) == 0 {
+		l.library.AddDetailsFetcher(ty, detailsFetcher)
+		return
+	}
+	fetcher, ok := l.library.GetDetailsFetcher(ty).(*scopedDetailsFetcher)
+	if !ok {
+		names := make([]string, 0, len(l.scope))
+		for name := range l.scope {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		fetcher = &scopedDetailsFetcher{names: names, fetchers: make(map[string]DetailsFetcher)}
+		l.library.AddDetailsFetcher(ty, fetcher)
+	}
+	fetcher.fetchers[fetcher.key(l.scope)] = detailsFetcher
+}
+
+// scopedEnumerator scopes resources to the scope they were enumerated in.
+// Resources knowing their own region, like AWS S3 buckets, keep it.
+type scopedEnumerator struct {
+	Enumerator
+	scope map[string]string
+}
+
+func (e *scopedEnumerator) Enumerate() ([]*resource.Resource, error) {
+	resources, err := e.Enumerator.Enumerate()
+	if err != nil {
+		return nil, err
+	}
+	for _, res := range resources {
+		if res == nil {
+			continue
+		}
+		if res.Scope == nil {
+			res.Scope = make(map[string]string, len(e.scope))
+		}
+		for name, value := range e.scope {
+			res.Scope[name] = value
+		}
+		if _, scoped := e.scope[ScopeRegion]; scoped && res.Attributes() != nil {
+			if region := res.Attributes().GetString(ScopeRegion); region != nil && *region != "" {
+				res.Scope[ScopeRegion] = *region
+			}
+		}
+	}
+	return resources, nil
+}
+
+// scopedDetailsFetcher reads details of a resource with the details fetcher of
+// its scope
+type scopedDetailsFetcher struct {
+	names    []string
+	fetchers map[string]DetailsFetcher
+}
+
+func (f *scopedDetailsFetcher) key(scope map[string]string) string {
+	values := make([]string, 0, len(f.names))
+	for _, name := range f.names {
+		values = append(values, scope[name])
+	}
+	return strings.Join(values, "/")
+}
+
+func (f *scopedDetailsFetcher) ReadDetails(res *resource.Resource) (*resource.Resource, error) {
+	key := f.key(res.Scope)
+	fetcher, exist := f.fetchers[key]
+	if !exist {
+		return nil, remoteerror.NewResourceScanningError(
+			errors.Errorf("%s is not scanned", key),
+			res.ResourceType(),
+			res.ResourceId(),
+		)
+	}
+	details, err := fetcher.ReadDetails(res)
+	if details != nil {
+		details.Scope = res.Scope
+	}
+	return details, err
+}
@@ -0,0 +1,106 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/cloudskiff/driftctl/pkg/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeDetailsFetcher struct {
+	name string
+}
+
+func (f *fakeDetailsFetcher) ReadDetails(res *resource.Resource) (*resource.Resource, error) {
+	return &resource.Resource{Id: res.Id, Type: res.Type, Attrs: &resource.Attributes{"fetcher": f.name}}, nil
+}
+
+func TestScopedLibrary_NoScope(t *testing.T) {
+	remoteLibrary := NewRemoteLibrary()
+	library := NewScopedLibrary(remoteLibrary, nil)
+
+	enumerator := &MockEnumerator{}
+	fetcher := &fakeDetailsFetcher{name: "us-east-1"}
+	library.AddEnumerator(enumerator)
+	library.AddDetailsFetcher("aws_vpc", fetcher)
+
+	assert.Equal(t, []Enumerator{enumerator}, remoteLibrary.Enumerators())
+	assert.Same(t, fetcher, remoteLibrary.GetDetailsFetcher("aws_vpc"))
+}
+
+func TestScopedLibrary_MultiRegion(t *testing.T) {
+	remoteLibrary := NewRemoteLibrary()
+
+	for _, region := range []string{"us-east-1", "eu-west-3"} {
+		library := NewScopedLibrary(remoteLibrary, map[string]string{"region": region})
+
+		enumerator := &MockEnumerator{}
+		enumerator.On("Enumerate").Return([]*resource.Resource{
+			{Id: "vpc-" + region, Type: "aws_vpc", Attrs: &resource.Attributes{}},
+			{Id: "bucket-" + region, Type: "aws_s3_bucket", Attrs: &resource.Attributes{"region": "ap-south-1"}},
+			{Id: "key-" + region, Type: "aws_kms_key"},
+		}, nil)
+		library.AddEnumerator(enumerator)
+		library.AddDetailsFetcher("aws_vpc", &fakeDetailsFetcher{name: region})
+	}
+
+	enumerators := remoteLibrary.Enumerators()
+	assert.Len(t, enumerators, 2)
+
+	resources, err := enumerators[1].Enumerate()
+	assert.NoError(t, err)
+	assert.Equal(t, []*resource.Resource{
+		{Id: "vpc-eu-west-3", Type: "aws_vpc", Attrs: &resource.Attributes{}, Scope: map[string]string{"region": "eu-west-3"}},
+		{Id: "bucket-eu-west-3", Type: "aws_s3_bucket", Attrs: &resource.Attributes{"region": "ap-south-1"}, Scope: map[string]string{"region": "ap-south-1"}},
+		{Id: "key-eu-west-3", Type: "aws_kms_key", Scope: map[string]string{"region": "eu-west-3"}},
+	}, resources)
+
+	fetcher := remoteLibrary.GetDetailsFetcher("aws_vpc")
+	res, err := fetcher.ReadDetails(resources[0])
+	assert.NoError(t, err)
+	assert.Equal(t, &resource.Attributes{"fetcher": "eu-west-3"}, res.Attrs)
+	assert.Equal(t, map[string]string{"region": "eu-west-3"}, res.Scope)
+
+	res, err = fetcher.ReadDetails(&resource.Resource{Id: "vpc-1", Type: "aws_vpc", Scope: map[string]string{"region": "us-east-1"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &resource.Attributes{"fetcher": "us-east-1"}, res.Attrs)
+
+	_, err = fetcher.ReadDetails(&resource.Resource{Id: "vpc-2", Type: "aws_vpc", Scope: map[string]string{"region": "ap-south-1"}})
+	assert.EqualError(t, err, "error scanning resource aws_vpc.vpc-2: ap-south-1 is not scanned")
+}
+
+func TestScopedLibrary_MultiAccount(t *testing.T) {
+	remoteLibrary := NewRemoteLibrary()
+
+	for _, account := range []string{"111111111111", "222222222222"} {
+		for _, region := range []string{"us-east-1", "eu-west-3"} {
+			library := NewScopedLibrary(remoteLibrary, map[string]string{
+				"account_id": account,
+				"region":     region,
+			})
+			enumerator := &MockEnumerator{}
+			enumerator.On("Enumerate").Return([]*resource.Resource{
+				{Id: "vpc", Type: "aws_vpc"},
+			}, nil)
+			library.AddEnumerator(enumerator)
+			library.AddDetailsFetcher("aws_vpc", &fakeDetailsFetcher{name: account + "/" + region})
+		}
+	}
+
+	enumerators := remoteLibrary.Enumerators()
+	assert.Len(t, enumerators, 4)
+
+	resources, err := enumerators[2].Enumerate()
+	assert.NoError(t, err)
+	assert.Equal(t, []*resource.Resource{
+		{Id: "vpc", Type: "aws_vpc", Scope: map[string]string{"account_id": "222222222222", "region": "us-east-1"}},
+	}, resources)
+
+	fetcher := remoteLibrary.GetDetailsFetcher("aws_vpc")
+	res, err := fetcher.ReadDetails(resources[0])
+	assert.NoError(t, err)
+	assert.Equal(t, &resource.Attributes{"fetcher": "222222222222/us-east-1"}, res.Attrs)
+
+	_, err = fetcher.ReadDetails(&resource.Resource{Id: "vpc", Type: "aws_vpc", Scope: map[string]string{"account_id": "333333333333", "region": "us-east-1"}})
+	assert.EqualError(t, err, "error scanning resource aws_vpc.vpc: 333333333333/us-east-1 is not scanned")
+}
@@ -1,7 +1,20 @@
 package config
 
+import "fmt"
+
 type GCPTerraformConfig struct {
 	Project string `cty:"project"`
 	Region  string `cty:"region"`
 	Zone    string `cty:"zone"`
+	// Scopes are the Cloud Asset Inventory scopes to scan, the project is
+	// scanned when empty
+	Scopes []string
+}
+
+// AssetScopes returns the scopes given to Cloud Asset Inventory requests
+func (c GCPTerraformConfig) AssetScopes() []string {
+	if len(c.Scopes) == 0 {
+		return []string{fmt.Sprintf("projects/%s", c.Project)}
+	}
+	return c.Scopes
 }
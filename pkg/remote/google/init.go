@@ -22,7 +22,8 @@ func Init(version string, alerter *alerter.Alerter,
 	progress output.Progress,
 	resourceSchemaRepository *resource.SchemaRepository,
 	factory resource.ResourceFactory,
-	configDir string) error {
+	configDir string,
+	opts common.RemoteOptions) error {
 
 	provider, err := NewGCPTerraformProvider(version, progress, configDir)
 	if err != nil {
@@ -51,59 +52,96 @@ func Init(version string, alerter *alerter.Alerter,
 		return err
 	}
 
-	assetRepository := repository.NewAssetRepository(assetClient, provider.GetConfig(), repositoryCache)
+	gcpConfig := provider.GetConfig()
+	gcpConfig.Scopes = opts.GCPScopes
+	assetRepository := repository.NewAssetRepository(assetClient, gcpConfig, repositoryCache)
 	storageRepository := repository.NewStorageRepository(storageClient, repositoryCache)
-	iamRepository := repository.NewCloudResourceManagerRepository(crmService, provider.GetConfig(), repositoryCache)
 
 	providerLibrary.AddProvider(terraform.GOOGLE, provider)
 	deserializer := resource.NewDeserializer(factory)
 
-	remoteLibrary.AddEnumerator(NewGoogleStorageBucketEnumerator(assetRepository, factory))
-	remoteLibrary.AddDetailsFetcher(google.GoogleStorageBucketResourceType, common.NewGenericDetailsFetcher(google.GoogleStorageBucketResourceType, provider, deserializer))
+	if len(gcpConfig.Scopes) == 0 {
+		iamRepository := repository.NewCloudResourceManagerRepository(crmService, gcpConfig, repositoryCache)
+		initProject(common.NewScopedLibrary(remoteLibrary, nil), assetRepository, storageRepository, iamRepository, provider, factory, deserializer)
+	} else {
+		projects, err := listProjects(assetRepository)
+		if err != nil {
+			return err
+		}
+		for _, project := range projects {
+			// Resources are scoped with their project whenever scopes are
+			// given, even if they resolve to a single project
+			scope := map[string]string{common.ScopeGCPProject: project.id}
+			projectConfig := gcpConfig
+			projectConfig.Project = project.id
+			iamRepository := repository.NewCloudResourceManagerRepository(crmService, projectConfig, cache.New(100))
+			initProject(
+				common.NewScopedLibrary(remoteLibrary, scope),
+				assetRepository.ForProject(project.number),
+				storageRepository,
+				iamRepository,
+				newProjectReader(provider, project.id),
+				factory,
+				deserializer,
+			)
+		}
+	}
+
+	err = resourceSchemaRepository.Init(terraform.GOOGLE, provider.Version(), provider.Schema())
+	if err != nil {
+		return err
+	}
+	google.InitResourcesMetadata(resourceSchemaRepository)
 
-	remoteLibrary.AddEnumerator(NewGoogleComputeFirewallEnumerator(assetRepository, factory))
-	remoteLibrary.AddDetailsFetcher(google.GoogleComputeFirewallResourceType, common.NewGenericDetailsFetcher(google.GoogleComputeFirewallResourceType, provider, deserializer))
+	return nil
+}
 
-	remoteLibrary.AddEnumerator(NewGoogleComputeRouterEnumerator(assetRepository, factory))
+// initProject registers enumerators and details fetchers of a project
+func initProject(library *common.ScopedLibrary,
+	assetRepository repository.AssetRepository,
+	storageRepository repository.StorageRepository,
+	iamRepository repository.CloudResourceManagerRepository,
+	reader terraform.ResourceReader,
+	factory resource.ResourceFactory,
+	deserializer *resource.Deserializer) {
+	library.AddEnumerator(NewGoogleStorageBucketEnumerator(assetRepository, factory))
+	library.AddDetailsFetcher(google.GoogleStorageBucketResourceType, common.NewGenericDetailsFetcher(google.GoogleStorageBucketResourceType, reader, deserializer))
 
-	remoteLibrary.AddEnumerator(NewGoogleComputeInstanceEnumerator(assetRepository, factory))
+	library.AddEnumerator(NewGoogleComputeFirewallEnumerator(assetRepository, factory))
+	library.AddDetailsFetcher(google.GoogleComputeFirewallResourceType, common.NewGenericDetailsFetcher(google.GoogleComputeFirewallResourceType, reader, deserializer))
 
-	remoteLibrary.AddEnumerator(NewGoogleProjectIamMemberEnumerator(iamRepository, factory))
-	remoteLibrary.AddDetailsFetcher(google.GoogleProjectIamMemberResourceType, common.NewGenericDetailsFetcher(google.GoogleProjectIamMemberResourceType, provider, deserializer))
+	library.AddEnumerator(NewGoogleComputeRouterEnumerator(assetRepository, factory))
 
-	remoteLibrary.AddEnumerator(NewGoogleStorageBucketIamMemberEnumerator(assetRepository, storageRepository, factory))
-	remoteLibrary.AddDetailsFetcher(google.GoogleStorageBucketIamMemberResourceType, common.NewGenericDetailsFetcher(google.GoogleStorageBucketIamMemberResourceType, provider, deserializer))
+	library.AddEnumerator(NewGoogleComputeInstanceEnumerator(assetRepository, factory))
 
-	remoteLibrary.AddEnumerator(NewGoogleComputeNetworkEnumerator(assetRepository, factory))
-	remoteLibrary.AddDetailsFetcher(google.GoogleComputeNetworkResourceType, common.NewGenericDetailsFetcher(google.GoogleComputeNetworkResourceType, provider, deserializer))
+	library.AddEnumerator(NewGoogleProjectIamMemberEnumerator(iamRepository, factory))
+	library.AddDetailsFetcher(google.GoogleProjectIamMemberResourceType, common.NewGenericDetailsFetcher(google.GoogleProjectIamMemberResourceType, reader, deserializer))
 
-	remoteLibrary.AddEnumerator(NewGoogleComputeSubnetworkEnumerator(assetRepository, factory))
-	remoteLibrary.AddDetailsFetcher(google.GoogleComputeSubnetworkResourceType, common.NewGenericDetailsFetcher(google.GoogleComputeSubnetworkResourceType, provider, deserializer))
+	library.AddEnumerator(NewGoogleStorageBucketIamMemberEnumerator(assetRepository, storageRepository, factory))
+	library.AddDetailsFetcher(google.GoogleStorageBucketIamMemberResourceType, common.NewGenericDetailsFetcher(google.GoogleStorageBucketIamMemberResourceType, reader, deserializer))
 
-	remoteLibrary.AddEnumerator(NewGoogleDNSManagedZoneEnumerator(assetRepository, factory))
+	library.AddEnumerator(NewGoogleComputeNetworkEnumerator(assetRepository, factory))
+	library.AddDetailsFetcher(google.GoogleComputeNetworkResourceType, common.NewGenericDetailsFetcher(google.GoogleComputeNetworkResourceType, reader, deserializer))
 
-	remoteLibrary.AddEnumerator(NewGoogleComputeInstanceGroupEnumerator(assetRepository, factory))
-	remoteLibrary.AddDetailsFetcher(google.GoogleComputeInstanceGroupResourceType, common.NewGenericDetailsFetcher(google.GoogleComputeInstanceGroupResourceType, provider, deserializer))
+	library.AddEnumerator(NewGoogleComputeSubnetworkEnumerator(assetRepository, factory))
+	library.AddDetailsFetcher(google.GoogleComputeSubnetworkResourceType, common.NewGenericDetailsFetcher(google.GoogleComputeSubnetworkResourceType, reader, deserializer))
 
-	remoteLibrary.AddEnumerator(NewGoogleBigqueryDatasetEnumerator(assetRepository, factory))
-	remoteLibrary.AddEnumerator(NewGoogleBigqueryTableEnumerator(assetRepository, factory))
+	library.AddEnumerator(NewGoogleDNSManagedZoneEnumerator(assetRepository, factory))
 
-	remoteLibrary.AddEnumerator(NewGoogleComputeAddressEnumerator(assetRepository, factory))
-	remoteLibrary.AddEnumerator(NewGoogleComputeGlobalAddressEnumerator(assetRepository, factory))
-	remoteLibrary.AddEnumerator(NewGoogleCloudFunctionsFunctionEnumerator(assetRepository, factory))
-	remoteLibrary.AddEnumerator(NewGoogleComputeDiskEnumerator(assetRepository, factory))
-	remoteLibrary.AddEnumerator(NewGoogleComputeImageEnumerator(assetRepository, factory))
-	remoteLibrary.AddEnumerator(NewGoogleBigTableInstanceEnumerator(assetRepository, factory))
-	remoteLibrary.AddEnumerator(NewGoogleBigtableTableEnumerator(assetRepository, factory))
-	remoteLibrary.AddEnumerator(NewGoogleSQLDatabaseInstanceEnumerator(assetRepository, factory))
-	remoteLibrary.AddEnumerator(NewGoogleComputeHealthCheckEnumerator(assetRepository, factory))
-	remoteLibrary.AddEnumerator(NewGoogleCloudRunServiceEnumerator(assetRepository, factory))
+	library.AddEnumerator(NewGoogleComputeInstanceGroupEnumerator(assetRepository, factory))
+	library.AddDetailsFetcher(google.GoogleComputeInstanceGroupResourceType, common.NewGenericDetailsFetcher(google.GoogleComputeInstanceGroupResourceType, reader, deserializer))
 
-	err = resourceSchemaRepository.Init(terraform.GOOGLE, provider.Version(), provider.Schema())
-	if err != nil {
-		return err
-	}
-	google.InitResourcesMetadata(resourceSchemaRepository)
+	library.AddEnumerator(NewGoogleBigqueryDatasetEnumerator(assetRepository, factory))
+	library.AddEnumerator(NewGoogleBigqueryTableEnumerator(assetRepository, factory))
 
-	return nil
+	library.AddEnumerator(NewGoogleComputeAddressEnumerator(assetRepository, factory))
+	library.AddEnumerator(NewGoogleComputeGlobalAddressEnumerator(assetRepository, factory))
+	library.AddEnumerator(NewGoogleCloudFunctionsFunctionEnumerator(assetRepository, factory))
+	library.AddEnumerator(NewGoogleComputeDiskEnumerator(assetRepository, factory))
+	library.AddEnumerator(NewGoogleComputeImageEnumerator(assetRepository, factory))
+	library.AddEnumerator(NewGoogleBigTableInstanceEnumerator(assetRepository, factory))
+	library.AddEnumerator(NewGoogleBigtableTableEnumerator(assetRepository, factory))
+	library.AddEnumerator(NewGoogleSQLDatabaseInstanceEnumerator(assetRepository, factory))
+	library.AddEnumerator(NewGoogleComputeHealthCheckEnumerator(assetRepository, factory))
+	library.AddEnumerator(NewGoogleCloudRunServiceEnumerator(assetRepository, factory))
 }
@@ -0,0 +1,43 @@
+package google
+
+import (
+	"strings"
+
+	"github.com/cloudskiff/driftctl/pkg/remote/google/repository"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// gcpProject is a project found in scanned scopes. Resources of Cloud Asset
+// Inventory reference projects by number while the provider expects IDs.
+type gcpProject struct {
+	id     string
+	number string
+}
+
+// listProjects returns projects of the scanned folders, organizations and
+// projects
+func listProjects(repo repository.AssetRepository) ([]gcpProject, error) {
+	assets, err := repo.SearchAllProjects()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to list projects to scan")
+	}
+
+	projects := make([]gcpProject, 0, len(assets))
+	for _, asset := range assets {
+		id, exist := asset.GetResource().GetData().GetFields()["projectId"]
+		if !exist || id.GetStringValue() == "" || len(asset.GetAncestors()) == 0 {
+			logrus.WithField("name", asset.GetName()).Warn("Unable to retrieve project ID")
+			continue
+		}
+		projects = append(projects, gcpProject{
+			id:     id.GetStringValue(),
+			number: strings.TrimPrefix(asset.GetAncestors()[0], "projects/"),
+		})
+	}
+
+	if len(projects) == 0 {
+		return nil, errors.New("no project found to scan")
+	}
+	return projects, nil
+}
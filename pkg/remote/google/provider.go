@@ -34,7 +34,12 @@ func NewGCPTerraformProvider(version string, progress output.Progress, configDir
 	tfProvider, err := terraform.NewTerraformProvider(installer, terraform.TerraformProviderConfig{
 		Name: p.name,
 		GetProviderConfig: func(alias string) interface{} {
-			return p.GetConfig()
+			config := p.GetConfig()
+			// Aliases are project IDs when projects are given as scopes
+			if alias != "" {
+				config.Project = alias
+			}
+			return config
 		},
 	}, progress)
 
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	asset "cloud.google.com/go/asset/apiv1"
 	"github.com/cloudskiff/driftctl/pkg/remote/cache"
@@ -33,6 +34,7 @@ const (
 	sqlDatabaseInstanceAssetType  = "sqladmin.googleapis.com/Instance"
 	healthCheckAssetType          = "compute.googleapis.com/HealthCheck"
 	cloudRunServiceAssetType      = "run.googleapis.com/Service"
+	projectAssetType              = "cloudresourcemanager.googleapis.com/Project"
 )
 
 type AssetRepository interface {
@@ -56,33 +58,47 @@ type AssetRepository interface {
 	SearchAllSQLDatabaseInstances() ([]*assetpb.Asset, error)
 	SearchAllHealthChecks() ([]*assetpb.ResourceSearchResult, error)
 	SearchAllCloudRunServices() ([]*assetpb.ResourceSearchResult, error)
+	SearchAllProjects() ([]*assetpb.Asset, error)
 }
 
 type assetRepository struct {
 	client *asset.Client
 	config config.GCPTerraformConfig
 	cache  cache.Cache
+	// project filters results on resources of a project, as projects/<number>
+	project string
 }
 
 func NewAssetRepository(client *asset.Client, config config.GCPTerraformConfig, c cache.Cache) *assetRepository {
 	return &assetRepository{
-		client,
-		config,
-		c,
+		client: client,
+		config: config,
+		cache:  c,
 	}
 }
 
+// ForProject returns a repository only returning resources of the given
+// project number. Results are shared with this repository through the cache,
+// so scopes are only searched once for every project.
+func (s assetRepository) ForProject(projectNumber string) *assetRepository {
+	s.project = fmt.Sprintf("projects/%s", projectNumber)
+	return &s
+}
+
+func (s assetRepository) inProject(project string) bool {
+	return s.project == "" || s.project == project
+}
+
 func (s assetRepository) listAllResources(ty string) ([]*assetpb.Asset, error) {
-	req := &assetpb.ListAssetsRequest{
-		Parent:      fmt.Sprintf("projects/%s", s.config.Project),
-		ContentType: assetpb.ContentType_RESOURCE,
-		AssetTypes: []string{
-			cloudFunctionsFunction,
-			bigtableInstanceAssetType,
-			bigtableTableAssetType,
-			sqlDatabaseInstanceAssetType,
-			computeGlobalAddressAssetType,
-		},
+	assetTypes := []string{
+		cloudFunctionsFunction,
+		bigtableInstanceAssetType,
+		bigtableTableAssetType,
+		sqlDatabaseInstanceAssetType,
+		computeGlobalAddressAssetType,
+	}
+	if len(s.config.Scopes) > 0 {
+		assetTypes = append(assetTypes, projectAssetType)
 	}
 	var results []*assetpb.Asset
 
@@ -94,23 +110,39 @@ func (s assetRepository) listAllResources(ty string) ([]*assetpb.Asset, error) {
 	}
 
 	if results == nil {
-		it := s.client.ListAssets(context.Background(), req)
-		for {
-			resource, err := it.Next()
-			if err == iterator.Done {
-				break
+		scopes := s.config.AssetScopes()
+		seen := make(map[string]struct{})
+		for _, scope := range scopes {
+			req := &assetpb.ListAssetsRequest{
+				Parent:      scope,
+				ContentType: assetpb.ContentType_RESOURCE,
+				AssetTypes:  assetTypes,
 			}
-			if err != nil {
-				return nil, err
+			it := s.client.ListAssets(context.Background(), req)
+			for {
+				resource, err := it.Next()
+				if err == iterator.Done {
+					break
+				}
+				if err != nil {
+					return nil, err
+				}
+				// Scopes may overlap, like a project of a scanned folder
+				if len(scopes) > 1 {
+					if _, exist := seen[resource.GetName()]; exist {
+						continue
+					}
+					seen[resource.GetName()] = struct{}{}
+				}
+				results = append(results, resource)
 			}
-			results = append(results, resource)
 		}
 		s.cache.Put(cacheKey, results)
 	}
 
 	filteredResults := []*assetpb.Asset{}
 	for _, result := range results {
-		if result.AssetType == ty {
+		if result.AssetType == ty && s.inProject(assetProject(result)) {
 			filteredResults = append(filteredResults, result)
 		}
 	}
@@ -119,25 +151,22 @@ func (s assetRepository) listAllResources(ty string) ([]*assetpb.Asset, error) {
 }
 
 func (s assetRepository) searchAllResources(ty string) ([]*assetpb.ResourceSearchResult, error) {
-	req := &assetpb.SearchAllResourcesRequest{
-		Scope: fmt.Sprintf("projects/%s", s.config.Project),
-		AssetTypes: []string{
-			storageBucketAssetType,
-			computeFirewallAssetType,
-			computeRouterAssetType,
-			computeInstanceAssetType,
-			computeNetworkAssetType,
-			computeSubnetworkAssetType,
-			dnsManagedZoneAssetType,
-			computeInstanceGroupAssetType,
-			bigqueryDatasetAssetType,
-			bigqueryTableAssetType,
-			computeAddressAssetType,
-			computeDiskAssetType,
-			computeImageAssetType,
-			healthCheckAssetType,
-			cloudRunServiceAssetType,
-		},
+	assetTypes := []string{
+		storageBucketAssetType,
+		computeFirewallAssetType,
+		computeRouterAssetType,
+		computeInstanceAssetType,
+		computeNetworkAssetType,
+		computeSubnetworkAssetType,
+		dnsManagedZoneAssetType,
+		computeInstanceGroupAssetType,
+		bigqueryDatasetAssetType,
+		bigqueryTableAssetType,
+		computeAddressAssetType,
+		computeDiskAssetType,
+		computeImageAssetType,
+		healthCheckAssetType,
+		cloudRunServiceAssetType,
 	}
 	var results []*assetpb.ResourceSearchResult
 
@@ -149,23 +178,37 @@ func (s assetRepository) searchAllResources(ty string) ([]*assetpb.ResourceSearc
 	}
 
 	if results == nil {
-		it := s.client.SearchAllResources(context.Background(), req)
-		for {
-			resource, err := it.Next()
-			if err == iterator.Done {
-				break
+		scopes := s.config.AssetScopes()
+		seen := make(map[string]struct{})
+		for _, scope := range scopes {
+			req := &assetpb.SearchAllResourcesRequest{
+				Scope:      scope,
+				AssetTypes: assetTypes,
 			}
-			if err != nil {
-				return nil, err
+			it := s.client.SearchAllResources(context.Background(), req)
+			for {
+				resource, err := it.Next()
+				if err == iterator.Done {
+					break
+				}
+				if err != nil {
+					return nil, err
+				}
+				if len(scopes) > 1 {
+					if _, exist := seen[resource.GetName()]; exist {
+						continue
+					}
+					seen[resource.GetName()] = struct{}{}
+				}
+				results = append(results, resource)
 			}
-			results = append(results, resource)
 		}
 		s.cache.Put(cacheKey, results)
 	}
 
 	filteredResults := []*assetpb.ResourceSearchResult{}
 	for _, result := range results {
-		if result.AssetType == ty {
+		if result.AssetType == ty && s.inProject(result.GetProject()) {
 			filteredResults = append(filteredResults, result)
 		}
 	}
@@ -252,3 +295,17 @@ func (s assetRepository) SearchAllHealthChecks() ([]*assetpb.ResourceSearchResul
 func (s assetRepository) SearchAllCloudRunServices() ([]*assetpb.ResourceSearchResult, error) {
 	return s.searchAllResources(cloudRunServiceAssetType)
 }
+
+// SearchAllProjects lists projects of the scanned scopes, scopes are only
+// expanded to their projects when given
+func (s assetRepository) SearchAllProjects() ([]*assetpb.Asset, error) {
+	return s.listAllResources(projectAssetType)
+}
+
+// assetProject returns the project of an asset, its first ancestor
+func assetProject(asset *assetpb.Asset) string {
+	if ancestors := asset.GetAncestors(); len(ancestors) > 0 && strings.HasPrefix(ancestors[0], "projects/") {
+		return ancestors[0]
+	}
+	return ""
+}
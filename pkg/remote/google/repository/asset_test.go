@@ -62,3 +62,65 @@ func Test_assetRepository_searchAllResources_CacheMiss(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Len(t, got, 1)
 }
+
+func Test_assetRepository_searchAllResources_Scopes(t *testing.T) {
+
+	expectedResults := []*assetpb.ResourceSearchResult{
+		{
+			AssetType: "google_fake_type",
+			Name:      "//fake.googleapis.com/projects/project-1/fakes/driftctl-unittest-1",
+			Project:   "projects/1",
+		},
+		{
+			AssetType: "google_fake_type",
+			Name:      "//fake.googleapis.com/projects/project-2/fakes/driftctl-unittest-2",
+			Project:   "projects/2",
+		},
+	}
+	assetClient, err := google.NewFakeAssetServer(expectedResults, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := cache.New(1)
+	repo := NewAssetRepository(assetClient, config.GCPTerraformConfig{Scopes: []string{"folders/123", "projects/project-1"}}, c)
+
+	got, err := repo.searchAllResources("google_fake_type")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"projects/1", "projects/2"}, []string{got[0].Project, got[1].Project})
+
+	got, err = repo.ForProject("2").searchAllResources("google_fake_type")
+	assert.Nil(t, err)
+	assert.Len(t, got, 1)
+	assert.Equal(t, "projects/2", got[0].Project)
+}
+
+func Test_assetRepository_listAllResources_Scopes(t *testing.T) {
+
+	expectedResults := []*assetpb.Asset{
+		{
+			AssetType: "google_fake_type",
+			Name:      "//fake.googleapis.com/projects/project-1/fakes/driftctl-unittest-1",
+			Ancestors: []string{"projects/1", "folders/123"},
+		},
+		{
+			AssetType: "google_fake_type",
+			Name:      "//fake.googleapis.com/projects/project-2/fakes/driftctl-unittest-2",
+			Ancestors: []string{"projects/2", "folders/123"},
+		},
+	}
+	assetClient, err := google.NewFakeAssertServerWithList(expectedResults, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := cache.New(1)
+	repo := NewAssetRepository(assetClient, config.GCPTerraformConfig{Scopes: []string{"folders/123", "projects/project-1"}}, c)
+
+	got, err := repo.listAllResources("google_fake_type")
+	assert.Nil(t, err)
+	assert.Len(t, got, 2)
+
+	got, err = repo.ForProject("1").listAllResources("google_fake_type")
+	assert.Nil(t, err)
+	assert.Len(t, got, 1)
+	assert.Equal(t, "//fake.googleapis.com/projects/project-1/fakes/driftctl-unittest-1", got[0].Name)
+}
@@ -0,0 +1,27 @@
+package google
+
+import (
+	"github.com/cloudskiff/driftctl/pkg/terraform"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// projectReader reads resources through the provider alias of a project, as
+// the provider is configured for a single project
+type projectReader struct {
+	reader  terraform.ResourceReader
+	project string
+}
+
+func newProjectReader(reader terraform.ResourceReader, project string) *projectReader {
+	return &projectReader{reader, project}
+}
+
+func (r *projectReader) ReadResource(args terraform.ReadResourceArgs) (*cty.Value, error) {
+	attributes := make(map[string]string, len(args.Attributes)+1)
+	for k, v := range args.Attributes {
+		attributes[k] = v
+	}
+	attributes["alias"] = r.project
+	args.Attributes = attributes
+	return r.reader.ReadResource(args)
+}
@@ -0,0 +1,63 @@
+package google
+
+import (
+	"testing"
+
+	"github.com/cloudskiff/driftctl/pkg/remote/google/repository"
+	"github.com/cloudskiff/driftctl/pkg/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/zclconf/go-cty/cty"
+	assetpb "google.golang.org/genproto/googleapis/cloud/asset/v1"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+type fakeResourceReader struct {
+	args []terraform.ReadResourceArgs
+}
+
+func (r *fakeResourceReader) ReadResource(args terraform.ReadResourceArgs) (*cty.Value, error) {
+	r.args = append(r.args, args)
+	val := cty.NullVal(cty.DynamicPseudoType)
+	return &val, nil
+}
+
+func TestProjectReader_ReadResource(t *testing.T) {
+	fake := &fakeResourceReader{}
+	reader := newProjectReader(fake, "project-1")
+
+	attributes := map[string]string{"name": "test"}
+	_, err := reader.ReadResource(terraform.ReadResourceArgs{Ty: "google_compute_network", ID: "network", Attributes: attributes})
+	assert.NoError(t, err)
+
+	assert.Equal(t, map[string]string{"name": "test", "alias": "project-1"}, fake.args[0].Attributes)
+	assert.Equal(t, map[string]string{"name": "test"}, attributes)
+}
+
+func TestListProjects(t *testing.T) {
+	repo := &repository.MockAssetRepository{}
+	repo.On("SearchAllProjects").Return([]*assetpb.Asset{
+		{
+			Name:      "//cloudresourcemanager.googleapis.com/projects/1",
+			Ancestors: []string{"projects/1", "folders/123"},
+			Resource: &assetpb.Resource{Data: &structpb.Struct{Fields: map[string]*structpb.Value{
+				"projectId": structpb.NewStringValue("project-1"),
+			}}},
+		},
+		{
+			Name:      "//cloudresourcemanager.googleapis.com/projects/2",
+			Ancestors: []string{"projects/2", "folders/123"},
+		},
+	}, nil)
+
+	projects, err := listProjects(repo)
+	assert.NoError(t, err)
+	assert.Equal(t, []gcpProject{{id: "project-1", number: "1"}}, projects)
+}
+
+func TestListProjects_NoProject(t *testing.T) {
+	repo := &repository.MockAssetRepository{}
+	repo.On("SearchAllProjects").Return([]*assetpb.Asset{}, nil)
+
+	_, err := listProjects(repo)
+	assert.EqualError(t, err, "no project found to scan")
+}
@@ -14,6 +14,9 @@ import (
 
 type ScannerOptions struct {
 	Deep bool
+	// Types restricts the enumeration to resources of these types, every
+	// supported type is enumerated when empty
+	Types []resource.ResourceType
 }
 
 type Scanner struct {
@@ -23,10 +26,12 @@ type Scanner struct {
 	alerter              alerter.AlerterInterface
 	options              ScannerOptions
 	filter               filter.Filter
+	// types holds the types to enumerate, nil means every type
+	types map[resource.ResourceType]struct{}
 }
 
 func NewScanner(remoteLibrary *common.RemoteLibrary, alerter alerter.AlerterInterface, options ScannerOptions, filter filter.Filter) *Scanner {
-	return &Scanner{
+	scanner := &Scanner{
 		enumeratorRunner:     parallel.NewParallelRunner(context.TODO(), 10),
 		detailsFetcherRunner: parallel.NewParallelRunner(context.TODO(), 10),
 		remoteLibrary:        remoteLibrary,
@@ -34,6 +39,30 @@ func NewScanner(remoteLibrary *common.RemoteLibrary, alerter alerter.AlerterInte
 		options:              options,
 		filter:               filter,
 	}
+	if len(options.Types) > 0 {
+		scanner.RestrictTypes(options.Types)
+	}
+	return scanner
+}
+
+// RestrictTypes only keeps given types among the types to enumerate, calling
+// it several times narrows the scan down to the types given on every call
+func (s *Scanner) RestrictTypes(types []resource.ResourceType) {
+	restricted := make(map[resource.ResourceType]struct{}, len(types))
+	for _, ty := range types {
+		if s.isTypeScanned(ty) {
+			restricted[ty] = struct{}{}
+		}
+	}
+	s.types = restricted
+}
+
+func (s *Scanner) isTypeScanned(ty resource.ResourceType) bool {
+	if s.types == nil {
+		return true
+	}
+	_, exist := s.types[ty]
+	return exist
 }
 
 func (s *Scanner) retrieveRunnerResults(runner *parallel.ParallelRunner) ([]*resource.Resource, error) {
@@ -60,6 +89,12 @@ loop:
 
 func (s *Scanner) scan() ([]*resource.Resource, error) {
 	for _, enumerator := range s.remoteLibrary.Enumerators() {
+		if !s.isTypeScanned(enumerator.SupportedType()) {
+			logrus.WithFields(logrus.Fields{
+				"type": enumerator.SupportedType(),
+			}).Debug("Ignored enumeration of resources since its type is not scanned")
+			continue
+		}
 		if s.filter.IsTypeIgnored(enumerator.SupportedType()) {
 			logrus.WithFields(logrus.Fields{
 				"type": enumerator.SupportedType(),
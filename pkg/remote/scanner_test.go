@@ -29,3 +29,34 @@ func TestScannerShouldIgnoreType(t *testing.T) {
 	assert.Nil(t, err)
 	fakeEnumerator.AssertExpectations(t)
 }
+
+func TestScannerShouldOnlyEnumerateRestrictedTypes(t *testing.T) {
+
+	// Initialize mocks
+	alerter := alerter.NewAlerter()
+	scannedEnumerator := &common.MockEnumerator{}
+	scannedEnumerator.On("SupportedType").Return(resource.ResourceType("ScannedType"))
+	scannedEnumerator.On("Enumerate").Return([]*resource.Resource{{Id: "scanned", Type: "ScannedType"}}, nil).Once()
+	restrictedEnumerator := &common.MockEnumerator{}
+	restrictedEnumerator.On("SupportedType").Return(resource.ResourceType("RestrictedType"))
+	optionEnumerator := &common.MockEnumerator{}
+	optionEnumerator.On("SupportedType").Return(resource.ResourceType("OptionType"))
+
+	remoteLibrary := common.NewRemoteLibrary()
+	remoteLibrary.AddEnumerator(scannedEnumerator)
+	remoteLibrary.AddEnumerator(restrictedEnumerator)
+	remoteLibrary.AddEnumerator(optionEnumerator)
+
+	testFilter := &filter.MockFilter{}
+	testFilter.On("IsTypeIgnored", resource.ResourceType("ScannedType")).Return(false)
+
+	s := NewScanner(remoteLibrary, alerter, ScannerOptions{Types: []resource.ResourceType{"ScannedType", "RestrictedType"}}, testFilter)
+	s.RestrictTypes([]resource.ResourceType{"ScannedType", "OptionType"})
+	got, err := s.Resources()
+	assert.Nil(t, err)
+	assert.Equal(t, []*resource.Resource{{Id: "scanned", Type: "ScannedType"}}, got)
+	scannedEnumerator.AssertExpectations(t)
+	restrictedEnumerator.AssertNotCalled(t, "Enumerate")
+	optionEnumerator.AssertNotCalled(t, "Enumerate")
+	testFilter.AssertExpectations(t)
+}
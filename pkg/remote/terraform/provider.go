@@ -165,9 +165,11 @@ func (p *TerraformProvider) ReadResource(args tf.ReadResourceArgs) (*cty.Value,
 	if p.grpcProviders[alias] == nil {
 		err := p.configure(alias)
 		if err != nil {
+			p.lock.Unlock()
 			return nil, err
 		}
 	}
+	grpcProvider := p.grpcProviders[alias]
 	p.lock.Unlock()
 
 	if args.Attributes != nil && len(args.Attributes) > 0 {
@@ -189,7 +191,7 @@ func (p *TerraformProvider) ReadResource(args tf.ReadResourceArgs) (*cty.Value,
 	r := retrier.New(retrier.ConstantBackoff(3, 100*time.Millisecond), nil)
 
 	err = r.Run(func() error {
-		resp := p.grpcProviders[alias].ReadResource(providers.ReadResourceRequest{
+		resp := grpcProvider.ReadResource(providers.ReadResourceRequest{
 			TypeName:     typ,
 			PriorState:   priorState,
 			Private:      []byte{},
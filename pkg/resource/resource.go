@@ -14,22 +14,37 @@ type Source interface {
 	Source() string
 	Namespace() string
 	InternalName() string
+	Workspace() string
+	// AttributesKnown tells if the IaC source describes resources attributes,
+	// resources are compared in deep mode only when it does
+	AttributesKnown() bool
 }
 
+// Kinds of serialized sources, Terraform states being the default one
+const (
+	SourceKindTerraformState      = ""
+	SourceKindCloudformationStack = "cloudformation"
+)
+
 type SerializableSource struct {
+	Kind string `json:"kind,omitempty"`
 	S    string `json:"source"`
 	Ns   string `json:"namespace"`
 	Name string `json:"internal_name"`
+	Ws   string `json:"workspace,omitempty"`
 }
 
 type TerraformStateSource struct {
 	State  string
 	Module string
 	Name   string
+	// WorkspaceName is the Terraform workspace of the state, it is only known
+	// when states are enumerated by workspace
+	WorkspaceName string
 }
 
 func NewTerraformStateSource(state, module, name string) *TerraformStateSource {
-	return &TerraformStateSource{state, module, name}
+	return &TerraformStateSource{State: state, Module: module, Name: name}
 }
 
 func (s *TerraformStateSource) Source() string {
@@ -44,12 +59,55 @@ func (s *TerraformStateSource) InternalName() string {
 	return s.Name
 }
 
+func (s *TerraformStateSource) Workspace() string {
+	return s.WorkspaceName
+}
+
+func (s *TerraformStateSource) AttributesKnown() bool {
+	return true
+}
+
+// CloudformationStackSource is the source of resources read from CloudFormation
+// stacks, stacks only tell resource ids so their attributes are unknown
+type CloudformationStackSource struct {
+	Stack     string
+	LogicalId string
+}
+
+func NewCloudformationStackSource(stack, logicalId string) *CloudformationStackSource {
+	return &CloudformationStackSource{Stack: stack, LogicalId: logicalId}
+}
+
+func (s *CloudformationStackSource) Source() string {
+	return s.Stack
+}
+
+func (s *CloudformationStackSource) Namespace() string {
+	return ""
+}
+
+func (s *CloudformationStackSource) InternalName() string {
+	return s.LogicalId
+}
+
+func (s *CloudformationStackSource) Workspace() string {
+	return ""
+}
+
+func (s *CloudformationStackSource) AttributesKnown() bool {
+	return false
+}
+
 type Resource struct {
 	Id     string
 	Type   string
 	Attrs  *Attributes
 	Sch    *Schema `json:"-" diff:"-"`
 	Source Source  `json:"-"`
+	// Scope locates a remote resource when several scopes of a remote are
+	// scanned, like AWS regions. It is not part of resource attributes as
+	// states do not know it.
+	Scope map[string]string `json:"-" diff:"-"`
 }
 
 func (r *Resource) Schema() *Schema {
@@ -68,6 +126,12 @@ func (r *Resource) Attributes() *Attributes {
 	return r.Attrs
 }
 
+// ScopeValue returns the value of a scope of the resource, empty when the
+// resource is not scoped
+func (r *Resource) ScopeValue(name string) string {
+	return r.Scope[name]
+}
+
 func (r *Resource) Src() Source {
 	return r.Source
 }
@@ -99,27 +163,65 @@ type ResourceFactory interface {
 }
 
 type SerializableResource struct {
-	Id     string              `json:"id"`
-	Type   string              `json:"type"`
-	Source *SerializableSource `json:"source,omitempty"`
+	Id         string              `json:"id"`
+	Type       string              `json:"type"`
+	Source     *SerializableSource `json:"source,omitempty"`
+	Scope      map[string]string   `json:"scope,omitempty"`
+	Attributes *Attributes         `json:"attributes,omitempty"`
 }
 
 func NewSerializableResource(res *Resource) *SerializableResource {
 	var src *SerializableSource
 	if res.Src() != nil {
 		src = &SerializableSource{
+			Kind: SourceKindTerraformState,
 			S:    res.Src().Source(),
 			Ns:   res.Src().Namespace(),
 			Name: res.Src().InternalName(),
+			Ws:   res.Src().Workspace(),
+		}
+		if _, fromStack := res.Src().(*CloudformationStackSource); fromStack {
+			src.Kind = SourceKindCloudformationStack
 		}
 	}
 	return &SerializableResource{
 		Id:     res.ResourceId(),
 		Type:   res.ResourceType(),
 		Source: src,
+		Scope:  res.Scope,
 	}
 }
 
+// NewSerializableResourceWithAttributes is the same as NewSerializableResource
+// but also includes resource's attributes
+func NewSerializableResourceWithAttributes(res *Resource) *SerializableResource {
+	serializable := NewSerializableResource(res)
+	serializable.Attributes = res.Attributes()
+	return serializable
+}
+
+// Resource rebuilds the resource from its serialized form, the schema is not
+// part of it and should be set by the caller if needed
+func (r *SerializableResource) Resource() *Resource {
+	res := &Resource{
+		Id:    r.Id,
+		Type:  r.Type,
+		Attrs: r.Attributes,
+		Scope: r.Scope,
+	}
+	if r.Source != nil {
+		switch r.Source.Kind {
+		case SourceKindCloudformationStack:
+			res.Source = NewCloudformationStackSource(r.Source.S, r.Source.Name)
+		default:
+			source := NewTerraformStateSource(r.Source.S, r.Source.Ns, r.Source.Name)
+			source.WorkspaceName = r.Source.Ws
+			res.Source = source
+		}
+	}
+	return res
+}
+
 type NormalizedResource interface {
 	NormalizeForState() (Resource, error)
 	NormalizeForProvider() (Resource, error)
@@ -1,6 +1,7 @@
 package resource
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -73,3 +74,48 @@ func TestSanitizeDefaults(t *testing.T) {
 		})
 	}
 }
+
+func TestSerializableResource_RoundTrip(t *testing.T) {
+	workspaceSource := NewTerraformStateSource("tfstate+s3://bucket/terraform.tfstate", "module", "name")
+	workspaceSource.WorkspaceName = "staging"
+
+	tests := []struct {
+		name     string
+		source   Source
+		wantKind string
+	}{
+		{
+			name:     "terraform state",
+			source:   workspaceSource,
+			wantKind: SourceKindTerraformState,
+		},
+		{
+			name:     "cloudformation stack",
+			source:   NewCloudformationStackSource("legacy-network", "Vpc"),
+			wantKind: SourceKindCloudformationStack,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := &Resource{
+				Id:     "id",
+				Type:   "aws_vpc",
+				Source: tt.source,
+				Scope:  map[string]string{"account_id": "111111111111", "region": "eu-west-3"},
+			}
+
+			content, err := json.Marshal(NewSerializableResource(res))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got SerializableResource
+			if err := json.Unmarshal(content, &got); err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tt.wantKind, got.Source.Kind)
+			assert.Equal(t, res, got.Resource())
+			assert.Equal(t, tt.source.AttributesKnown(), got.Resource().Src().AttributesKnown())
+		})
+	}
+}
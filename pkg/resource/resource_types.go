@@ -1,5 +1,7 @@
 package resource
 
+import "sort"
+
 type ResourceType string
 
 var supportedTypes = map[string]ResourceTypeMeta{
@@ -228,3 +230,43 @@ type ResourceTypeMeta struct {
 func (ty ResourceTypeMeta) GetChildrenTypes() []ResourceType {
 	return ty.children
 }
+
+// GetRelatedTypes returns given types along with every type linked to them by
+// a parent or child relationship, recursively. Middlewares build or drop
+// resources of a type from resources of its related types, so all of them
+// have to be read to reconcile resources of given types.
+func GetRelatedTypes(types []ResourceType) []ResourceType {
+	related := make(map[ResourceType]struct{}, len(types))
+	queue := append([]ResourceType{}, types...)
+	for len(queue) > 0 {
+		ty := queue[0]
+		queue = queue[1:]
+		if _, exist := related[ty]; exist {
+			continue
+		}
+		related[ty] = struct{}{}
+		queue = append(queue, GetMeta(ty).GetChildrenTypes()...)
+		queue = append(queue, getParentTypes(ty)...)
+	}
+
+	results := make([]ResourceType, 0, len(related))
+	for ty := range related {
+		results = append(results, ty)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i] < results[j]
+	})
+	return results
+}
+
+func getParentTypes(ty ResourceType) []ResourceType {
+	parents := make([]ResourceType, 0)
+	for parent, meta := range supportedTypes {
+		for _, child := range meta.children {
+			if child == ty {
+				parents = append(parents, ResourceType(parent))
+			}
+		}
+	}
+	return parents
+}
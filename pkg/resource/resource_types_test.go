@@ -0,0 +1,47 @@
+package resource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRelatedTypes(t *testing.T) {
+	cases := map[string]struct {
+		types    []ResourceType
+		expected []ResourceType
+	}{
+		"no types": {
+			types:    []ResourceType{},
+			expected: []ResourceType{},
+		},
+		"types without relationships": {
+			types:    []ResourceType{"aws_ami", "aws_dynamodb_table"},
+			expected: []ResourceType{"aws_ami", "aws_dynamodb_table"},
+		},
+		"child type with several parents": {
+			types: []ResourceType{"aws_route"},
+			expected: []ResourceType{
+				"aws_default_route_table",
+				"aws_default_vpc",
+				"aws_internet_gateway",
+				"aws_route",
+				"aws_route_table",
+			},
+		},
+		"parent type": {
+			types: []ResourceType{"aws_default_security_group", "aws_ami"},
+			expected: []ResourceType{
+				"aws_ami",
+				"aws_default_security_group",
+				"aws_security_group",
+				"aws_security_group_rule",
+			},
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, c.expected, GetRelatedTypes(c.types))
+		})
+	}
+}
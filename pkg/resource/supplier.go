@@ -9,3 +9,10 @@ type StoppableSupplier interface {
 	Supplier
 	Stop()
 }
+
+// TypeRestrictableSupplier is a Supplier able to only retrieve resources of
+// some types, to avoid reading resources that will not be analyzed
+type TypeRestrictableSupplier interface {
+	Supplier
+	RestrictTypes(types []ResourceType)
+}
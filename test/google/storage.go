@@ -0,0 +1,88 @@
+package google
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/option"
+)
+
+// fakeStoragePageSize is kept small so pagination is exercised by tests
+const fakeStoragePageSize = 2
+
+// FakeStorageServer implements the subset of the GCS JSON API used to list
+// and download objects. Objects are indexed by BUCKET/OBJECT_NAME.
+type FakeStorageServer struct {
+	Objects map[string]string
+}
+
+func (s *FakeStorageServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if strings.HasPrefix(r.URL.Path, "/storage/v1/b/") {
+		s.list(w, r)
+		return
+	}
+
+	content, exist := s.Objects[strings.TrimPrefix(r.URL.Path, "/")]
+	if !exist {
+		http.Error(w, "Not Found", http.StatusNotFound)
+		return
+	}
+	_, _ = w.Write([]byte(content))
+}
+
+func (s *FakeStorageServer) list(w http.ResponseWriter, r *http.Request) {
+	// Path is /storage/v1/b/BUCKET/o
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/storage/v1/b/"), "/")
+	bucket := parts[0]
+	prefix := r.URL.Query().Get("prefix")
+
+	names := make([]string, 0)
+	for key := range s.Objects {
+		if name := strings.TrimPrefix(key, bucket+"/"); name != key && strings.HasPrefix(name, prefix) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	start, _ := strconv.Atoi(r.URL.Query().Get("pageToken"))
+	end := start + fakeStoragePageSize
+	response := map[string]interface{}{"kind": "storage#objects"}
+	if end < len(names) {
+		response["nextPageToken"] = strconv.Itoa(end)
+	} else {
+		end = len(names)
+	}
+
+	items := make([]map[string]string, 0, end-start)
+	for _, name := range names[start:end] {
+		items = append(items, map[string]string{
+			"kind":   "storage#object",
+			"bucket": bucket,
+			"name":   name,
+			"size":   strconv.Itoa(len(s.Objects[bucket+"/"+name])),
+		})
+	}
+	response["items"] = items
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// NewFakeStorageServer starts a local fake GCS server and returns a client
+// targeting it, the server is closed when the test ends
+func NewFakeStorageServer(t *testing.T, objects map[string]string) (*storage.Client, error) {
+	server := httptest.NewTLSServer(&FakeStorageServer{Objects: objects})
+	t.Cleanup(server.Close)
+	return storage.NewClient(context.Background(),
+		option.WithEndpoint(server.URL+"/storage/v1/"),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(server.Client()),
+	)
+}